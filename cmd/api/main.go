@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"os/signal"
@@ -29,6 +30,10 @@ import (
 // @description API REST para gerenciar Colaboradores e Departamentos
 // @host localhost:8080
 // @BasePath /api/v1
+// @securityDefinitions.apikey AdminToken
+// @in header
+// @name Authorization
+// @description "Bearer " seguido do ADMIN_TOKEN
 func main() {
 	logger, _ := zap.NewProduction()
 	defer logger.Sync()
@@ -45,26 +50,58 @@ func main() {
 		logger.Fatal("Failed to connect to database", zap.Error(err))
 	}
 
-	redisAddr := fmt.Sprintf("%s:%s", cfg.RedisHost, cfg.RedisPort)
+	backend, err := database.NewCache(database.CacheOptions{
+		Backend:    cfg.CacheBackend,
+		RedisAddr:  fmt.Sprintf("%s:%s", cfg.RedisHost, cfg.RedisPort),
+		MaxEntries: cfg.CacheMaxEntries,
+		LocalTTL:   cfg.CacheLocalTTL,
+	}, logger)
+	if err != nil {
+		logger.Fatal("Failed to set up cache", zap.Error(err))
+	}
+	if closer, ok := backend.(io.Closer); ok {
+		defer closer.Close()
+	}
 
-	cache := database.NewRedisCache(redisAddr)
+	cache := database.NewBreakerCache(backend, database.BreakerOptions{
+		FailureThreshold: cfg.CacheFailureThreshold,
+		OpenTimeout:      cfg.CacheOpenTimeout,
+		RetryAttempts:    cfg.CacheRetryAttempts,
+		RetryBackoff:     cfg.CacheRetryBackoff,
+	}, logger)
 
 	colaboradorRepo := repository.NewColaboradorRepository(db)
 	departamentoRepo := repository.NewDepartamentoRepository(db)
+	unitOfWork := repository.NewUnitOfWork(db)
+
+	cacheOpts := service.CacheOptions{
+		TTL:                     cfg.CacheTTL,
+		StaleTTL:                cfg.CacheStaleTTL,
+		ColaboradoresListTTL:    cfg.CacheColaboradoresListTTL,
+		DepartamentosListTTL:    cfg.CacheDepartamentosListTTL,
+		GerenteColaboradoresTTL: cfg.CacheGerenteColaboradoresTTL,
+	}
 
-	colaboradorSvc := service.NewColaboradorService(colaboradorRepo, departamentoRepo, cache, logger)
-	departamentoSvc := service.NewDepartamentoService(departamentoRepo, colaboradorRepo, cache, logger)
+	colaboradorSvc := service.NewColaboradorService(colaboradorRepo, departamentoRepo, unitOfWork, cache, cacheOpts, logger)
+	departamentoSvc := service.NewDepartamentoService(departamentoRepo, colaboradorRepo, unitOfWork, cache, cacheOpts, logger)
+	adminSvc := service.NewAdminService(departamentoRepo, unitOfWork, cache, logger)
 
 	colaboradorHandler := handler.NewColaboradorHandler(colaboradorSvc, logger)
 	departamentoHandler := handler.NewDepartamentoHandler(departamentoSvc, logger)
+	adminHandler := handler.NewAdminHandler(adminSvc, cfg.PurgeRetentionDays, logger)
 
-	router := setupRouter(colaboradorHandler, departamentoHandler)
+	router := setupRouter(cfg, cache, colaboradorHandler, departamentoHandler, adminHandler)
 
 	srv := &http.Server{
 		Addr:    fmt.Sprintf(":%s", cfg.Port),
 		Handler: router,
 	}
 
+	background, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+	go adminSvc.WatchConsistency(background, cfg.ConsistencyCheckInterval)
+	go cache.Run(background)
+
 	go func() {
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			logger.Fatal("Failed to start server", zap.Error(err))
@@ -78,6 +115,7 @@ func main() {
 	<-quit
 
 	logger.Info("Shutting down server...")
+	stopBackground()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -89,35 +127,54 @@ func main() {
 	logger.Info("Server exited gracefully")
 }
 
-func setupRouter(colaboradorHandler *handler.ColaboradorHandler, departamentoHandler *handler.DepartamentoHandler) *gin.Engine {
+func setupRouter(cfg *config.Config, cache *database.BreakerCache, colaboradorHandler *handler.ColaboradorHandler, departamentoHandler *handler.DepartamentoHandler, adminHandler *handler.AdminHandler) *gin.Engine {
 	router := gin.Default()
 
 	router.Use(handler.PrometheusMiddleware())
 
+	// The API keeps serving from Postgres while the cache is unavailable, so
+	// a broken cache only degrades the status.
 	router.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{"status": "ok"})
+		cacheHealth := cache.Health()
+		status := "ok"
+		if cacheHealth.State != database.BreakerClosed.String() || cacheHealth.PendingInvalidations > 0 {
+			status = "degraded"
+		}
+		c.JSON(200, gin.H{"status": status, "cache": cacheHealth})
 	})
 
 	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 	router.GET("/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	ifMatch := handler.RequireIfMatch(cfg.RequireIfMatch)
+
 	v1 := router.Group("/api/v1")
 	{
 		colaboradores := v1.Group("/colaboradores")
 		{
 			colaboradores.POST("", colaboradorHandler.Create)
 			colaboradores.GET("/:id", colaboradorHandler.GetByID)
-			colaboradores.PUT("/:id", colaboradorHandler.Update)
+			colaboradores.PUT("/:id", ifMatch, colaboradorHandler.Update)
+			colaboradores.PATCH("/:id", ifMatch, colaboradorHandler.Patch)
 			colaboradores.DELETE("/:id", colaboradorHandler.Delete)
+			colaboradores.POST("/:id/restore", colaboradorHandler.Restore)
 			colaboradores.POST("/listar", colaboradorHandler.List)
 		}
 
 		departamentos := v1.Group("/departamentos")
 		{
 			departamentos.POST("", departamentoHandler.Create)
+			departamentos.POST("/bootstrap", departamentoHandler.Bootstrap)
 			departamentos.GET("/:id", departamentoHandler.GetByID)
-			departamentos.PUT("/:id", departamentoHandler.Update)
+			departamentos.GET("/:id/ancestrais", departamentoHandler.GetAncestors)
+			departamentos.PUT("/:id", ifMatch, departamentoHandler.Update)
+			departamentos.PATCH("/:id", ifMatch, departamentoHandler.Patch)
 			departamentos.DELETE("/:id", departamentoHandler.Delete)
+			departamentos.POST("/:id/restore", departamentoHandler.Restore)
+			departamentos.POST("/:id/gerente", departamentoHandler.ChangeGerente)
+			departamentos.POST("/:id/mover", departamentoHandler.Move)
+			departamentos.POST("/:id/merge", departamentoHandler.Merge)
+			departamentos.POST("/:id/split", departamentoHandler.Split)
 			departamentos.POST("/listar", departamentoHandler.List)
 		}
 
@@ -125,6 +182,19 @@ func setupRouter(colaboradorHandler *handler.ColaboradorHandler, departamentoHan
 		{
 			gerentes.GET("/:id/colaboradores", departamentoHandler.GetColaboradoresByGerente)
 		}
+
+		v1.GET("/organograma", departamentoHandler.Organograma)
+		v1.GET("/organograma/export", departamentoHandler.ExportOrganograma)
+
+		// Purge destroys history for good, so /admin is only served with a
+		// token to guard it.
+		if cfg.AdminToken != "" {
+			admin := v1.Group("/admin", handler.RequireAdminToken(cfg.AdminToken))
+			{
+				admin.POST("/purge", adminHandler.Purge)
+				admin.GET("/consistency", adminHandler.ConsistencyReport)
+			}
+		}
 	}
 
 	return router
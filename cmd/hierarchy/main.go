@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.uber.org/zap"
+	gormlogger "gorm.io/gorm/logger"
+
+	"takehome-go/internal/config"
+	"takehome-go/internal/database"
+	"takehome-go/internal/repository"
+)
+
+// hierarchy maintains the departamento closure table outside the API:
+//
+//	go run ./cmd/hierarchy rebuild
+func main() {
+	logger, _ := zap.NewProduction()
+	defer logger.Sync()
+
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, "uso: hierarchy rebuild")
+		os.Exit(2)
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		logger.Fatal("Failed to load .env variables", zap.Error(err))
+	}
+
+	postgresDsn := fmt.Sprintf("postgres://%s:%s@%s:5432/%s?sslmode=disable", cfg.PostgresUser, cfg.PostgresPass, cfg.PostgresHost, cfg.PostgresDb)
+
+	db, err := database.Connect(postgresDsn)
+	if err != nil {
+		logger.Fatal("Failed to connect to database", zap.Error(err))
+	}
+	db.Logger = db.Logger.LogMode(gormlogger.Warn)
+
+	ctx := context.Background()
+	departamentoRepo := repository.NewDepartamentoRepository(db)
+
+	switch os.Args[1] {
+	case "rebuild":
+		rows, err := departamentoRepo.RebuildClosure(ctx)
+		if err != nil {
+			logger.Fatal("Failed to rebuild closure table", zap.Error(err))
+		}
+		logger.Info("Closure table rebuilt", zap.Int64("rows", rows))
+
+	default:
+		fmt.Fprintf(os.Stderr, "comando desconhecido: %s\n", os.Args[1])
+		os.Exit(2)
+	}
+}
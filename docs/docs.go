@@ -15,6 +15,99 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/admin/consistency": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Lista os departamentos cujo gerente não existe, foi removido ou pertence a outro departamento",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Relatório de consistência",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ConsistencyReport"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/purge": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Remove definitivamente colaboradores e departamentos excluídos há mais dias que o período de retenção. O período pode ser estendido, mas nunca reduzido abaixo de PURGE_RETENTION_DAYS",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Expurgar registros removidos",
+                "parameters": [
+                    {
+                        "description": "Período de retenção em dias (padrão e mínimo: PURGE_RETENTION_DAYS)",
+                        "name": "purge",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PurgeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PurgeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/colaboradores": {
             "post": {
                 "description": "Cria um novo colaborador",
@@ -52,6 +145,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "409": {
                         "description": "Conflict",
                         "schema": {
@@ -82,7 +181,7 @@ const docTemplate = `{
                 "summary": "Listar colaboradores",
                 "parameters": [
                     {
-                        "description": "Filtros (nome, cpf, rg, departamento_id)",
+                        "description": "Filtros (nome, cpf, rg, departamento_id, include_deleted)",
                         "name": "filters",
                         "in": "body",
                         "schema": {
@@ -148,6 +247,12 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/dto.ColaboradorResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versão do registro"
+                            }
                         }
                     },
                     "404": {
@@ -178,6 +283,12 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "Versão esperada (ETag)",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
                     {
                         "description": "Dados do colaborador",
                         "name": "colaborador",
@@ -193,6 +304,12 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/model.Colaborador"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versão do registro"
+                            }
                         }
                     },
                     "400": {
@@ -222,7 +339,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Remove um colaborador",
+                "description": "Remove um colaborador. Se ele for gerente de algum departamento, informe quem assume cada um: successors[\u003cdepartamento_id\u003e]=\u003ccolaborador_id\u003e por departamento e/ou successor_gerente_id para os demais, sempre um colaborador do próprio departamento. Sem sucessor para algum deles a API responde 409 listando os departamentos pendentes",
                 "consumes": [
                     "application/json"
                 ],
@@ -240,50 +357,109 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Colaborador que assume os departamentos gerenciados sem sucessor próprio",
+                        "name": "successor_gerente_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "object",
+                        "description": "Sucessor por departamento: successors[\u003cdepartamento_id\u003e]=\u003ccolaborador_id\u003e",
+                        "name": "successors",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "204": {
                         "description": "No Content"
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "details": {
+                                            "$ref": "#/definitions/dto.ColaboradorDependents"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
                     }
                 }
-            }
-        },
-        "/departamentos": {
-            "post": {
-                "description": "Cria um novo departamento",
+            },
+            "patch": {
+                "description": "Aplica um JSON Merge Patch (RFC 7396): campos ausentes são mantidos e null limpa o campo",
                 "consumes": [
-                    "application/json"
+                    "application/merge-patch+json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "departamentos"
+                    "colaboradores"
                 ],
-                "summary": "Criar departamento",
+                "summary": "Atualizar colaborador parcialmente",
                 "parameters": [
                     {
-                        "description": "Dados do departamento",
-                        "name": "departamento",
+                        "type": "string",
+                        "description": "ID do colaborador",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Versão esperada (ETag)",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Campos a alterar",
+                        "name": "colaborador",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateDepartamentoRequest"
+                            "$ref": "#/definitions/dto.PatchColaboradorRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Departamento"
+                            "$ref": "#/definitions/model.Colaborador"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versão do registro"
+                            }
                         }
                     },
                     "400": {
@@ -298,62 +474,32 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/departamentos/listar": {
-            "post": {
-                "description": "Lista departamentos com filtros e paginação",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "departamentos"
-                ],
-                "summary": "Listar departamentos",
-                "parameters": [
-                    {
-                        "description": "Filtros (nome, gerente_nome, departamento_superior_id)",
-                        "name": "filters",
-                        "in": "body",
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Página",
-                        "name": "page",
-                        "in": "query"
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
                     },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Tamanho da página",
-                        "name": "page_size",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
-                            "$ref": "#/definitions/dto.ListDepartamentosResponse"
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "428": {
+                        "description": "Precondition Required",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -361,9 +507,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/departamentos/{id}": {
-            "get": {
-                "description": "Retorna um departamento com sua árvore hierárquica completa",
+        "/colaboradores/{id}/restore": {
+            "post": {
+                "description": "Desfaz a exclusão lógica de um colaborador",
                 "consumes": [
                     "application/json"
                 ],
@@ -371,13 +517,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "departamentos"
+                    "colaboradores"
                 ],
-                "summary": "Buscar departamento por ID",
+                "summary": "Restaurar colaborador",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID do departamento",
+                        "description": "ID do colaborador",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -387,7 +533,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.DepartamentoResponse"
+                            "$ref": "#/definitions/model.Colaborador"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "404": {
@@ -395,11 +547,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
                     }
                 }
-            },
-            "put": {
-                "description": "Atualiza os dados de um departamento",
+            }
+        },
+        "/departamentos": {
+            "post": {
+                "description": "Cria um novo departamento",
                 "consumes": [
                     "application/json"
                 ],
@@ -409,28 +569,21 @@ const docTemplate = `{
                 "tags": [
                     "departamentos"
                 ],
-                "summary": "Atualizar departamento",
+                "summary": "Criar departamento",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "ID do departamento",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "description": "Dados do departamento",
                         "name": "departamento",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateDepartamentoRequest"
+                            "$ref": "#/definitions/dto.CreateDepartamentoRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "$ref": "#/definitions/model.Departamento"
                         }
@@ -454,9 +607,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Remove um departamento",
+            }
+        },
+        "/departamentos/bootstrap": {
+            "post": {
+                "description": "Cria, de forma atômica, um departamento e um novo colaborador como seu gerente",
                 "consumes": [
                     "application/json"
                 ],
@@ -466,20 +621,842 @@ const docTemplate = `{
                 "tags": [
                     "departamentos"
                 ],
-                "summary": "Deletar departamento",
+                "summary": "Criar departamento com seu primeiro gerente",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ID do departamento",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Dados do departamento e do gerente",
+                        "name": "departamento",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BootstrapDepartamentoRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Departamento"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/departamentos/listar": {
+            "post": {
+                "description": "Lista departamentos com filtros e paginação",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "departamentos"
+                ],
+                "summary": "Listar departamentos",
+                "parameters": [
+                    {
+                        "description": "Filtros (nome, gerente_nome, departamento_superior_id, include_deleted)",
+                        "name": "filters",
+                        "in": "body",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Página",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Tamanho da página",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ListDepartamentosResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/departamentos/{id}": {
+            "get": {
+                "description": "Retorna um departamento com sua árvore hierárquica completa",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "departamentos"
+                ],
+                "summary": "Buscar departamento por ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do departamento",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DepartamentoResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versão do registro"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Atualiza os dados de um departamento",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "departamentos"
+                ],
+                "summary": "Atualizar departamento",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do departamento",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Versão esperada (ETag)",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Dados do departamento",
+                        "name": "departamento",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateDepartamentoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Departamento"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versão do registro"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove um departamento. Se houver colaboradores ou subdepartamentos, informe para onde movê-los ou use cascade para removê-los junto; caso contrário a API responde 409 listando-os",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "departamentos"
+                ],
+                "summary": "Deletar departamento",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do departamento",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Departamento que receberá os colaboradores",
+                        "name": "reassign_colaboradores_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Novo departamento superior dos subdepartamentos diretos",
+                        "name": "reparent_children_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Remove junto o que não for movido (colaboradores e toda a subárvore)",
+                        "name": "cascade",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "details": {
+                                            "$ref": "#/definitions/dto.DepartamentoDependents"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Aplica um JSON Merge Patch (RFC 7396): campos ausentes são mantidos e null limpa o campo",
+                "consumes": [
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "departamentos"
+                ],
+                "summary": "Atualizar departamento parcialmente",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do departamento",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Versão esperada (ETag)",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Campos a alterar",
+                        "name": "departamento",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PatchDepartamentoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Departamento"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versão do registro"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/departamentos/{id}/ancestrais": {
+            "get": {
+                "description": "Retorna o caminho da raiz até o departamento (breadcrumb), com os gerentes e a profundidade de cada nível",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "departamentos"
+                ],
+                "summary": "Listar ancestrais do departamento",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do departamento",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.DepartamentoAncestral"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/departamentos/{id}/gerente": {
+            "post": {
+                "description": "Nomeia um novo gerente, transferindo-o para o departamento se necessário, registra a data de efetivação e, opcionalmente, realoca o gerente anterior, tudo de forma atômica. A troca vale imediatamente: effective_date apenas retroage gerente_desde e não pode estar no futuro",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "departamentos"
+                ],
+                "summary": "Trocar gerente do departamento",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do departamento",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Versão esperada (ETag)",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Novo gerente e opções da sucessão",
+                        "name": "sucessao",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ChangeGerenteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Departamento"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versão do registro"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/departamentos/{id}/merge": {
+            "post": {
+                "description": "Incorpora o departamento ao destino em uma única transação: transfere colaboradores, move subdepartamentos, define o gerente que permanece e arquiva (exclusão lógica) ou remove definitivamente a origem",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "departamentos"
+                ],
+                "summary": "Fundir departamentos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do departamento de origem",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Versão esperada da origem (ETag)",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Destino e opções da fusão",
+                        "name": "fusao",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MergeDepartamentoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MergeDepartamentoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/departamentos/{id}/mover": {
+            "post": {
+                "description": "Move o departamento, com toda a sua subárvore, para baixo de um novo superior (null torna-o raiz) e retorna o caminho até a raiz antes e depois",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "departamentos"
+                ],
+                "summary": "Mover departamento",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do departamento",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Versão esperada (ETag)",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Novo departamento superior",
+                        "name": "destino",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MoveDepartamentoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MoveDepartamentoResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versão do registro"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/departamentos/{id}/restore": {
+            "post": {
+                "description": "Desfaz a exclusão lógica de um departamento, restaurando junto os colaboradores removidos na mesma exclusão. Se o gerente tiver sido transferido ou removido por outra operação, informe gerente_id para escolher quem assume; um colaborador de outro departamento é transferido para o restaurado",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "departamentos"
+                ],
+                "summary": "Restaurar departamento",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do departamento",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Colaborador que assume a gerência do departamento restaurado",
+                        "name": "gerente_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Departamento"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/departamentos/{id}/split": {
+            "post": {
+                "description": "Cria, de forma atômica, um departamento irmão (padrão) ou filho a partir deste, movendo os colaboradores e subdepartamentos diretos informados; o gerente deve estar entre os colaboradores movidos",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "departamentos"
+                ],
+                "summary": "Dividir departamento",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do departamento de origem",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Novo departamento e o que ele recebe",
+                        "name": "divisao",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SplitDepartamentoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SplitDepartamentoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/gerentes/{id}/colaboradores": {
+            "get": {
+                "description": "Retorna todos os colaboradores dos departamentos subordinados ao gerente",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gerentes"
+                ],
+                "summary": "Buscar colaboradores por gerente",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do gerente",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Colaborador"
+                            }
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -489,9 +1466,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/gerentes/{id}/colaboradores": {
+        "/organograma": {
             "get": {
-                "description": "Retorna todos os colaboradores dos departamentos subordinados ao gerente",
+                "description": "Retorna todos os departamentos raiz com suas árvores. Nós cortados por max_depth trazem um cursor para expandir o ramo depois",
                 "consumes": [
                     "application/json"
                 ],
@@ -499,26 +1476,46 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "gerentes"
+                    "organograma"
                 ],
-                "summary": "Buscar colaboradores por gerente",
+                "summary": "Organograma completo",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Níveis abaixo dos nós iniciais (sem limite se omitido)",
+                        "name": "max_depth",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Inclui a quantidade de colaboradores de cada departamento",
+                        "name": "include_headcount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Inclui os colaboradores de cada departamento",
+                        "name": "include_colaboradores",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "ID do gerente",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Cursor de um nó para expandir apenas o seu ramo",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Colaborador"
-                            }
+                            "$ref": "#/definitions/dto.OrganogramaResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "404": {
@@ -529,19 +1526,281 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "/organograma/export": {
+            "get": {
+                "description": "Desenha o organograma completo (departamentos com gerente e headcount, ligados ao superior) em Graphviz DOT, Mermaid ou SVG",
+                "produces": [
+                    "text/plain",
+                    "image/svg+xml"
+                ],
+                "tags": [
+                    "organograma"
+                ],
+                "summary": "Exportar organograma",
+                "parameters": [
+                    {
+                        "enum": [
+                            "dot",
+                            "mermaid",
+                            "svg"
+                        ],
+                        "type": "string",
+                        "description": "Formato de saída",
+                        "name": "format",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
         }
     },
     "definitions": {
+        "apperror.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "rule": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BootstrapDepartamentoRequest": {
+            "type": "object",
+            "required": [
+                "gerente",
+                "nome"
+            ],
+            "properties": {
+                "departamento_superior_id": {
+                    "type": "string"
+                },
+                "gerente": {
+                    "$ref": "#/definitions/dto.BootstrapGerenteRequest"
+                },
+                "nome": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BootstrapGerenteRequest": {
+            "type": "object",
+            "required": [
+                "cpf",
+                "nome"
+            ],
+            "properties": {
+                "cpf": {
+                    "type": "string"
+                },
+                "nome": {
+                    "type": "string"
+                },
+                "rg": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ChangeGerenteRequest": {
+            "type": "object",
+            "required": [
+                "gerente_id"
+            ],
+            "properties": {
+                "effective_date": {
+                    "type": "string"
+                },
+                "gerente_id": {
+                    "type": "string"
+                },
+                "relocate_outgoing_to": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ColaboradorDependents": {
+            "type": "object",
+            "properties": {
+                "departamentos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DependentSummary"
+                    }
+                }
+            }
+        },
         "dto.ColaboradorResponse": {
             "type": "object",
             "properties": {
                 "cpf": {
                     "type": "string"
                 },
-                "created_at": {
-                    "type": "string"
+                "created_at": {
+                    "type": "string"
+                },
+                "departamento_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "nome": {
+                    "type": "string"
+                },
+                "nome_gerente": {
+                    "type": "string"
+                },
+                "rg": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ConsistencyReport": {
+            "type": "object",
+            "properties": {
+                "checked_at": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "violations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GerenteViolation"
+                    }
+                }
+            }
+        },
+        "dto.CreateColaboradorRequest": {
+            "type": "object",
+            "required": [
+                "cpf",
+                "departamento_id",
+                "nome"
+            ],
+            "properties": {
+                "cpf": {
+                    "type": "string"
+                },
+                "departamento_id": {
+                    "type": "string"
+                },
+                "nome": {
+                    "type": "string"
+                },
+                "rg": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateDepartamentoRequest": {
+            "type": "object",
+            "required": [
+                "gerente_id",
+                "nome"
+            ],
+            "properties": {
+                "departamento_superior_id": {
+                    "type": "string"
+                },
+                "gerente_id": {
+                    "type": "string"
+                },
+                "nome": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DepartamentoAncestral": {
+            "type": "object",
+            "properties": {
+                "departamento_superior_id": {
+                    "type": "string"
+                },
+                "depth": {
+                    "type": "integer"
+                },
+                "gerente": {
+                    "$ref": "#/definitions/model.Colaborador"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "nome": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DepartamentoDependents": {
+            "type": "object",
+            "properties": {
+                "colaboradores": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DependentSummary"
+                    }
+                },
+                "subdepartamentos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DependentSummary"
+                    }
+                }
+            }
+        },
+        "dto.DepartamentoRef": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "nome": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DepartamentoResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "departamento_superior_id": {
+                    "type": "string"
+                },
+                "depth": {
+                    "type": "integer"
                 },
-                "departamento_id": {
+                "gerente": {
+                    "$ref": "#/definitions/model.Colaborador"
+                },
+                "gerente_desde": {
                     "type": "string"
                 },
                 "id": {
@@ -550,69 +1809,203 @@ const docTemplate = `{
                 "nome": {
                     "type": "string"
                 },
-                "nome_gerente": {
+                "subdepartamentos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Departamento"
+                    }
+                },
+                "updated_at": {
                     "type": "string"
                 },
-                "rg": {
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.DependentSummary": {
+            "type": "object",
+            "properties": {
+                "id": {
                     "type": "string"
                 },
-                "updated_at": {
+                "nome": {
                     "type": "string"
                 }
             }
         },
-        "dto.CreateColaboradorRequest": {
+        "dto.GerenteViolation": {
             "type": "object",
-            "required": [
-                "cpf",
-                "departamento_id",
-                "nome"
-            ],
             "properties": {
-                "cpf": {
+                "departamento_id": {
                     "type": "string"
                 },
-                "departamento_id": {
+                "departamento_nome": {
                     "type": "string"
                 },
-                "nome": {
+                "gerente_departamento_id": {
                     "type": "string"
                 },
-                "rg": {
+                "gerente_id": {
+                    "type": "string"
+                },
+                "reason": {
                     "type": "string"
                 }
             }
         },
-        "dto.CreateDepartamentoRequest": {
+        "dto.ListColaboradoresResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Colaborador"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ListDepartamentosResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Departamento"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.MergeDepartamentoRequest": {
             "type": "object",
             "required": [
-                "gerente_id",
-                "nome"
+                "target_id"
             ],
             "properties": {
-                "departamento_superior_id": {
+                "source_action": {
+                    "type": "string",
+                    "enum": [
+                        "archive",
+                        "delete"
+                    ]
+                },
+                "surviving_gerente": {
+                    "type": "string",
+                    "enum": [
+                        "target",
+                        "source"
+                    ]
+                },
+                "target_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.MergeDepartamentoResponse": {
+            "type": "object",
+            "properties": {
+                "acao_origem": {
                     "type": "string"
                 },
+                "colaboradores_movidos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DependentSummary"
+                    }
+                },
+                "departamento": {
+                    "$ref": "#/definitions/model.Departamento"
+                },
                 "gerente_id": {
                     "type": "string"
                 },
-                "nome": {
+                "origem": {
+                    "$ref": "#/definitions/dto.DepartamentoRef"
+                },
+                "subdepartamentos_movidos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DepartamentoRef"
+                    }
+                }
+            }
+        },
+        "dto.MoveDepartamentoRequest": {
+            "type": "object",
+            "properties": {
+                "departamento_superior_id": {
                     "type": "string"
                 }
             }
         },
-        "dto.DepartamentoResponse": {
+        "dto.MoveDepartamentoResponse": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "caminho_anterior": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DepartamentoRef"
+                    }
+                },
+                "caminho_atual": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DepartamentoRef"
+                    }
+                },
+                "departamento": {
+                    "$ref": "#/definitions/model.Departamento"
+                }
+            }
+        },
+        "dto.OrganogramaNode": {
+            "type": "object",
+            "properties": {
+                "colaboradores": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Colaborador"
+                    }
+                },
+                "cursor": {
                     "type": "string"
                 },
                 "departamento_superior_id": {
                     "type": "string"
                 },
+                "depth": {
+                    "type": "integer"
+                },
                 "gerente": {
                     "$ref": "#/definitions/model.Colaborador"
                 },
+                "headcount": {
+                    "type": "integer"
+                },
                 "id": {
                     "type": "string"
                 },
@@ -622,57 +2015,132 @@ const docTemplate = `{
                 "subdepartamentos": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.Departamento"
+                        "$ref": "#/definitions/dto.OrganogramaNode"
                     }
-                },
-                "updated_at": {
-                    "type": "string"
                 }
             }
         },
-        "dto.ListColaboradoresResponse": {
+        "dto.OrganogramaResponse": {
             "type": "object",
             "properties": {
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.Colaborador"
+                        "$ref": "#/definitions/dto.OrganogramaNode"
                     }
+                }
+            }
+        },
+        "dto.PatchColaboradorRequest": {
+            "type": "object",
+            "properties": {
+                "cpf": {
+                    "type": "string"
                 },
-                "page": {
-                    "type": "integer"
+                "departamento_id": {
+                    "type": "string"
                 },
-                "page_size": {
-                    "type": "integer"
+                "nome": {
+                    "type": "string"
                 },
-                "total": {
+                "rg": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PatchDepartamentoRequest": {
+            "type": "object",
+            "properties": {
+                "departamento_superior_id": {
+                    "type": "string"
+                },
+                "gerente_id": {
+                    "type": "string"
+                },
+                "nome": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PurgeRequest": {
+            "type": "object",
+            "properties": {
+                "retention_days": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "dto.PurgeResponse": {
+            "type": "object",
+            "properties": {
+                "colaboradores": {
                     "type": "integer"
                 },
-                "total_pages": {
+                "deleted_before": {
+                    "type": "string"
+                },
+                "departamentos": {
                     "type": "integer"
                 }
             }
         },
-        "dto.ListDepartamentosResponse": {
+        "dto.SplitDepartamentoRequest": {
             "type": "object",
+            "required": [
+                "colaborador_ids",
+                "gerente_id",
+                "nome"
+            ],
             "properties": {
-                "data": {
+                "colaborador_ids": {
                     "type": "array",
+                    "minItems": 1,
                     "items": {
-                        "$ref": "#/definitions/model.Departamento"
+                        "type": "string"
                     }
                 },
-                "page": {
-                    "type": "integer"
+                "gerente_id": {
+                    "type": "string"
                 },
-                "page_size": {
-                    "type": "integer"
+                "nome": {
+                    "type": "string"
                 },
-                "total": {
-                    "type": "integer"
+                "position": {
+                    "type": "string",
+                    "enum": [
+                        "sibling",
+                        "child"
+                    ]
                 },
-                "total_pages": {
-                    "type": "integer"
+                "subdepartamento_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.SplitDepartamentoResponse": {
+            "type": "object",
+            "properties": {
+                "colaboradores_movidos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DependentSummary"
+                    }
+                },
+                "departamento": {
+                    "$ref": "#/definitions/model.Departamento"
+                },
+                "origem": {
+                    "$ref": "#/definitions/dto.DepartamentoRef"
+                },
+                "subdepartamentos_movidos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DepartamentoRef"
+                    }
                 }
             }
         },
@@ -710,10 +2178,29 @@ const docTemplate = `{
         "handler.ErrorResponse": {
             "type": "object",
             "properties": {
-                "error": {
+                "code": {
                     "type": "string"
                 },
-                "message": {
+                "detail": {
+                    "type": "string"
+                },
+                "details": {},
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/apperror.FieldError"
+                    }
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
                     "type": "string"
                 }
             }
@@ -727,6 +2214,9 @@ const docTemplate = `{
                 "created_at": {
                     "type": "string"
                 },
+                "deleted_at": {
+                    "type": "string"
+                },
                 "departamento": {
                     "$ref": "#/definitions/model.Departamento"
                 },
@@ -744,6 +2234,9 @@ const docTemplate = `{
                 },
                 "updated_at": {
                     "type": "string"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
@@ -753,15 +2246,25 @@ const docTemplate = `{
                 "created_at": {
                     "type": "string"
                 },
+                "deleted_at": {
+                    "type": "string"
+                },
                 "departamento_superior": {
                     "$ref": "#/definitions/model.Departamento"
                 },
                 "departamento_superior_id": {
                     "type": "string"
                 },
+                "depth": {
+                    "description": "Depth is the distance to the root (0 for a root). It is not stored and\nis only filled in by hierarchy queries.",
+                    "type": "integer"
+                },
                 "gerente": {
                     "$ref": "#/definitions/model.Colaborador"
                 },
+                "gerente_desde": {
+                    "type": "string"
+                },
                 "gerente_id": {
                     "type": "string"
                 },
@@ -779,9 +2282,20 @@ const docTemplate = `{
                 },
                 "updated_at": {
                     "type": "string"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "AdminToken": {
+            "description": "\"Bearer \" seguido do ADMIN_TOKEN",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
     }
 }`
 
@@ -0,0 +1,142 @@
+package apperror
+
+import "errors"
+
+type Kind string
+
+const (
+	KindBadRequest   Kind = "bad_request"
+	KindUnauthorized Kind = "unauthorized"
+	KindNotFound     Kind = "not_found"
+	KindConflict     Kind = "conflict"
+	KindValidation   Kind = "validation"
+	KindCycle        Kind = "cycle"
+	KindInternal     Kind = "internal"
+
+	KindUnsupportedMediaType Kind = "unsupported_media_type"
+	KindPreconditionFailed   Kind = "precondition_failed"
+	KindPreconditionRequired Kind = "precondition_required"
+)
+
+// FieldError describes a single invalid input field and the rule it broke.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// Error is the domain error returned by services. Code is stable and meant
+// for clients to branch on; Message is the localized text shown to users.
+// Details optionally carries structured context, such as the records that
+// block an operation.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Field   string
+	Message string
+	Fields  []FieldError
+	Details any
+	Err     error
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is matches errors by code so wrapped or copied catalogue errors still
+// compare equal to their sentinel.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	return e.Code == t.Code
+}
+
+// WithFields returns a copy of e carrying the given field violations, leaving
+// catalogue sentinels untouched.
+func (e *Error) WithFields(fields ...FieldError) *Error {
+	cp := *e
+	cp.Fields = fields
+	return &cp
+}
+
+// WithField returns a copy of e reported against field, for rules applied
+// to list items such as "colaborador_ids[2]".
+func (e *Error) WithField(field string) *Error {
+	cp := *e
+	cp.Field = field
+	return &cp
+}
+
+// WithDetails returns a copy of e carrying details.
+func (e *Error) WithDetails(details any) *Error {
+	cp := *e
+	cp.Details = details
+	return &cp
+}
+
+func New(kind Kind, code, field, message string) *Error {
+	return &Error{
+		Kind:    kind,
+		Code:    code,
+		Field:   field,
+		Message: message,
+	}
+}
+
+func BadRequest(code, field, message string) *Error {
+	return New(KindBadRequest, code, field, message)
+}
+
+func Unauthorized(code, message string) *Error {
+	return New(KindUnauthorized, code, "", message)
+}
+
+func NotFound(code, field, message string) *Error {
+	return New(KindNotFound, code, field, message)
+}
+
+func Conflict(code, field, message string) *Error {
+	return New(KindConflict, code, field, message)
+}
+
+func Validation(code, field, message string) *Error {
+	return New(KindValidation, code, field, message)
+}
+
+func Cycle(code, field, message string) *Error {
+	return New(KindCycle, code, field, message)
+}
+
+func UnsupportedMediaType(code, message string) *Error {
+	return New(KindUnsupportedMediaType, code, "", message)
+}
+
+func PreconditionFailed(code, message string) *Error {
+	return New(KindPreconditionFailed, code, "", message)
+}
+
+func PreconditionRequired(code, message string) *Error {
+	return New(KindPreconditionRequired, code, "", message)
+}
+
+// Internal wraps an infrastructure failure; the cause is kept for logging
+// but never exposed in the message.
+func Internal(message string, err error) *Error {
+	e := New(KindInternal, CodeInternal, "", message)
+	e.Err = err
+	return e
+}
+
+func KindOf(err error) Kind {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Kind
+	}
+	return KindInternal
+}
@@ -0,0 +1,136 @@
+package apperror
+
+const (
+	CodeInternal = "internal_error"
+
+	CodeUnsupportedMediaType = "unsupported_media_type"
+
+	CodeInvalidID        = "invalid_id"
+	CodeInvalidBody      = "invalid_body"
+	CodeInvalidQuery     = "invalid_query"
+	CodeValidationFailed = "validation_failed"
+
+	CodeUnauthorized = "unauthorized"
+
+	CodeColaboradorNotFound          = "colaborador_not_found"
+	CodeDepartamentoNotFound         = "departamento_not_found"
+	CodeGerenteNotFound              = "gerente_not_found"
+	CodeDepartamentoSuperiorNotFound = "departamento_superior_not_found"
+
+	CodeNomeRequired         = "nome_required"
+	CodeCPFRequired          = "cpf_required"
+	CodeDepartamentoRequired = "departamento_required"
+	CodeGerenteRequired      = "gerente_required"
+
+	CodeCPFInvalid = "cpf_invalid"
+	CodeRGInvalid  = "rg_invalid"
+	CodeCPFTaken   = "cpf_taken"
+	CodeRGTaken    = "rg_taken"
+
+	CodeDepartamentoDeleted         = "departamento_deleted"
+	CodeGerenteDeleted              = "gerente_deleted"
+	CodeDepartamentoSuperiorDeleted = "departamento_superior_deleted"
+
+	CodeGerenteOutsideDepartamento = "gerente_outside_departamento"
+	CodeGerenteTransferred         = "gerente_transferred"
+	CodeHierarchyCycle             = "hierarchy_cycle"
+	CodeSuperiorUnchanged          = "superior_unchanged"
+
+	CodeDepartamentoHasDependents = "departamento_has_dependents"
+	CodeReassignTargetNotFound    = "reassign_target_not_found"
+	CodeReassignTargetInvalid     = "reassign_target_invalid"
+	CodeReparentTargetNotFound    = "reparent_target_not_found"
+	CodeReparentTargetInvalid     = "reparent_target_invalid"
+	CodeMergeTargetNotFound       = "merge_target_not_found"
+	CodeMergeTargetInvalid        = "merge_target_invalid"
+	CodeSplitColaboradorOutside   = "split_colaborador_outside"
+	CodeSplitSubdeptOutside       = "split_subdepartamento_outside"
+	CodeSplitGerenteNotMoved      = "split_gerente_not_moved"
+
+	CodeColaboradorIsGerente         = "colaborador_is_gerente"
+	CodeSuccessorNotFound            = "successor_gerente_not_found"
+	CodeSuccessorInvalid             = "successor_gerente_invalid"
+	CodeSuccessorOutsideDepartamento = "successor_gerente_outside_departamento"
+	CodeSuccessorNotManaged          = "successor_departamento_not_managed"
+
+	CodeGerenteUnchanged       = "gerente_unchanged"
+	CodeGerenteManagesOther    = "gerente_manages_other_departamento"
+	CodeRelocateTargetNotFound = "relocate_target_not_found"
+	CodeRelocateTargetInvalid  = "relocate_target_invalid"
+	CodeEffectiveDateFuture    = "effective_date_in_future"
+
+	CodeGerenteCannotLeave = "gerente_cannot_leave"
+	CodeGerenteInvariant   = "gerente_invariant_violated"
+
+	CodeRetentionBelowMinimum = "retention_below_minimum"
+
+	CodePreconditionFailed   = "precondition_failed"
+	CodePreconditionRequired = "precondition_required"
+	CodeConcurrentUpdate     = "concurrent_update"
+)
+
+var (
+	ErrUnsupportedMediaType = UnsupportedMediaType(CodeUnsupportedMediaType, "Content-Type não suportado; use application/merge-patch+json")
+
+	ErrInvalidID    = BadRequest(CodeInvalidID, "id", "ID inválido")
+	ErrInvalidBody  = BadRequest(CodeInvalidBody, "", "Dados inválidos")
+	ErrInvalidQuery = BadRequest(CodeInvalidQuery, "", "Parâmetros de consulta inválidos")
+
+	ErrUnauthorized = Unauthorized(CodeUnauthorized, "Token de administração ausente ou inválido")
+
+	ErrColaboradorNotFound          = NotFound(CodeColaboradorNotFound, "id", "Colaborador não encontrado")
+	ErrDepartamentoNotFound         = NotFound(CodeDepartamentoNotFound, "departamento_id", "Departamento não encontrado")
+	ErrGerenteNotFound              = NotFound(CodeGerenteNotFound, "gerente_id", "Gerente não encontrado")
+	ErrDepartamentoSuperiorNotFound = NotFound(CodeDepartamentoSuperiorNotFound, "departamento_superior_id", "Departamento superior não encontrado")
+
+	ErrNomeRequired         = Validation(CodeNomeRequired, "nome", "Nome é obrigatório")
+	ErrCPFRequired          = Validation(CodeCPFRequired, "cpf", "CPF é obrigatório")
+	ErrDepartamentoRequired = Validation(CodeDepartamentoRequired, "departamento_id", "Departamento é obrigatório")
+	ErrGerenteRequired      = Validation(CodeGerenteRequired, "gerente_id", "Gerente é obrigatório")
+
+	ErrCPFInvalid = Validation(CodeCPFInvalid, "cpf", "CPF inválido")
+	ErrRGInvalid  = Validation(CodeRGInvalid, "rg", "RG inválido")
+	ErrCPFTaken   = Conflict(CodeCPFTaken, "cpf", "CPF já cadastrado")
+	ErrRGTaken    = Conflict(CodeRGTaken, "rg", "RG já cadastrado")
+
+	ErrDepartamentoDeleted         = Conflict(CodeDepartamentoDeleted, "departamento_id", "Departamento do colaborador foi removido; restaure-o primeiro")
+	ErrGerenteDeleted              = Conflict(CodeGerenteDeleted, "gerente_id", "Gerente do departamento foi removido; informe gerente_id para escolher quem assume")
+	ErrDepartamentoSuperiorDeleted = Conflict(CodeDepartamentoSuperiorDeleted, "departamento_superior_id", "Departamento superior foi removido; restaure-o primeiro")
+
+	ErrGerenteOutsideDepartamento = Validation(CodeGerenteOutsideDepartamento, "gerente_id", "Gerente deve pertencer ao mesmo departamento")
+	ErrGerenteTransferred         = Conflict(CodeGerenteTransferred, "gerente_id", "Gerente do departamento foi transferido; informe gerente_id para trazê-lo de volta ou escolher outro")
+	ErrHierarchyCycle             = Cycle(CodeHierarchyCycle, "departamento_superior_id", "Operação criaria um ciclo na hierarquia de departamentos")
+	ErrSuperiorUnchanged          = Validation(CodeSuperiorUnchanged, "departamento_superior_id", "Departamento já está sob esse superior")
+
+	ErrDepartamentoHasDependents = Conflict(CodeDepartamentoHasDependents, "", "Departamento possui colaboradores ou subdepartamentos; informe para onde movê-los ou use cascade")
+	ErrReassignTargetNotFound    = NotFound(CodeReassignTargetNotFound, "reassign_colaboradores_to", "Departamento de destino dos colaboradores não encontrado")
+	ErrReassignTargetInvalid     = Validation(CodeReassignTargetInvalid, "reassign_colaboradores_to", "Departamento de destino dos colaboradores também seria removido")
+	ErrReparentTargetNotFound    = NotFound(CodeReparentTargetNotFound, "reparent_children_to", "Novo departamento superior não encontrado")
+	ErrReparentTargetInvalid     = Cycle(CodeReparentTargetInvalid, "reparent_children_to", "Novo departamento superior não pode ser o próprio departamento nem um de seus subdepartamentos")
+	ErrMergeTargetNotFound       = NotFound(CodeMergeTargetNotFound, "target_id", "Departamento de destino não encontrado")
+	ErrMergeTargetInvalid        = Cycle(CodeMergeTargetInvalid, "target_id", "Departamento de destino não pode ser a própria origem nem um de seus subdepartamentos")
+	ErrSplitColaboradorOutside   = Validation(CodeSplitColaboradorOutside, "colaborador_ids", "Colaborador não pertence ao departamento de origem")
+	ErrSplitSubdeptOutside       = Validation(CodeSplitSubdeptOutside, "subdepartamento_ids", "Subdepartamento não é filho direto do departamento de origem")
+	ErrSplitGerenteNotMoved      = Validation(CodeSplitGerenteNotMoved, "gerente_id", "Gerente deve estar entre os colaboradores movidos")
+
+	ErrColaboradorIsGerente         = Conflict(CodeColaboradorIsGerente, "", "Colaborador é gerente de departamentos sem sucessor; informe successor_gerente_id ou successors[<departamento_id>] para transferir a gerência")
+	ErrSuccessorNotFound            = NotFound(CodeSuccessorNotFound, "successor_gerente_id", "Sucessor não encontrado")
+	ErrSuccessorInvalid             = Validation(CodeSuccessorInvalid, "successor_gerente_id", "Sucessor não pode ser o próprio colaborador")
+	ErrSuccessorOutsideDepartamento = Validation(CodeSuccessorOutsideDepartamento, "successor_gerente_id", "Sucessor deve pertencer ao departamento que irá gerenciar")
+	ErrSuccessorNotManaged          = Validation(CodeSuccessorNotManaged, "successors", "Departamento informado em successors não é gerenciado pelo colaborador")
+
+	ErrGerenteUnchanged       = Validation(CodeGerenteUnchanged, "gerente_id", "Colaborador já é o gerente do departamento")
+	ErrGerenteManagesOther    = Conflict(CodeGerenteManagesOther, "gerente_id", "Novo gerente já gerencia outro departamento")
+	ErrRelocateTargetNotFound = NotFound(CodeRelocateTargetNotFound, "relocate_outgoing_to", "Departamento de destino do gerente anterior não encontrado")
+	ErrRelocateTargetInvalid  = Validation(CodeRelocateTargetInvalid, "relocate_outgoing_to", "Gerente anterior deve ser realocado para outro departamento")
+	ErrEffectiveDateFuture    = Validation(CodeEffectiveDateFuture, "effective_date", "Data de efetivação não pode estar no futuro; trocas agendadas não são suportadas")
+
+	ErrGerenteCannotLeave = Conflict(CodeGerenteCannotLeave, "departamento_id", "Colaborador é gerente do seu departamento e não pode ser transferido; troque o gerente primeiro")
+	ErrGerenteInvariant   = Conflict(CodeGerenteInvariant, "", "Operação deixaria departamentos com gerente fora do próprio departamento")
+
+	ErrRetentionBelowMinimum = Validation(CodeRetentionBelowMinimum, "retention_days", "Período de retenção menor que o mínimo configurado em PURGE_RETENTION_DAYS")
+
+	ErrPreconditionFailed   = PreconditionFailed(CodePreconditionFailed, "O registro foi modificado; recarregue e tente novamente")
+	ErrPreconditionRequired = PreconditionRequired(CodePreconditionRequired, "Cabeçalho If-Match obrigatório")
+	ErrConcurrentUpdate     = Conflict(CodeConcurrentUpdate, "", "O registro foi modificado por outra operação")
+)
@@ -0,0 +1,63 @@
+package apperror
+
+// Violations accumulates domain rule failures so a request can report all of
+// them at once instead of stopping at the first one.
+type Violations struct {
+	errs   *[]*Error
+	prefix string
+}
+
+func NewViolations() *Violations {
+	return &Violations{errs: &[]*Error{}}
+}
+
+func (v *Violations) Add(err *Error) {
+	if v.prefix != "" {
+		nested := *err
+		nested.Field = v.prefix + "." + err.Field
+		err = &nested
+	}
+	*v.errs = append(*v.errs, err)
+}
+
+// Nested returns a view that records into v with every field name prefixed,
+// so rules shared between payloads report e.g. "gerente.cpf".
+func (v *Violations) Nested(prefix string) *Violations {
+	if v.prefix != "" {
+		prefix = v.prefix + "." + prefix
+	}
+	return &Violations{errs: v.errs, prefix: prefix}
+}
+
+func (v *Violations) Empty() bool {
+	return len(*v.errs) == 0
+}
+
+// Err returns nil when nothing was recorded and the error itself when there
+// is a single violation. Several violations are folded into one error that
+// keeps their shared kind, or falls back to a validation error when they
+// disagree (e.g. a duplicate CPF together with an invalid RG).
+func (v *Violations) Err() error {
+	errs := *v.errs
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	}
+
+	kind := errs[0].Kind
+	fields := make([]FieldError, 0, len(errs))
+	for _, e := range errs {
+		if e.Kind != kind {
+			kind = KindValidation
+		}
+		fields = append(fields, FieldError{
+			Field:   e.Field,
+			Rule:    e.Code,
+			Message: e.Message,
+		})
+	}
+
+	return New(kind, CodeValidationFailed, "", "Dados inválidos").WithFields(fields...)
+}
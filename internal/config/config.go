@@ -1,6 +1,9 @@
 package config
 
 import (
+	"fmt"
+	"time"
+
 	env "github.com/caarlos0/env/v10"
 )
 
@@ -10,8 +13,33 @@ type Config struct {
 	PostgresPass string `env:"POSTGRES_PASS,required"`
 	PostgresHost string `env:"POSTGRES_HOST,required"`
 	PostgresDb   string `env:"POSTGRES_DB,required"`
-	RedisHost    string `env:"REDIS_HOST,required"`
-	RedisPort    string `env:"REDIS_PORT,required"`
+	RedisHost    string `env:"REDIS_HOST"`
+	RedisPort    string `env:"REDIS_PORT" envDefault:"6379"`
+
+	// CacheBackend is one of redis, memory, tiered or none. Only redis and
+	// tiered need REDIS_HOST.
+	CacheBackend    string        `env:"CACHE_BACKEND" envDefault:"redis"`
+	CacheMaxEntries int           `env:"CACHE_MAX_ENTRIES" envDefault:"10000"`
+	CacheLocalTTL   time.Duration `env:"CACHE_LOCAL_TTL" envDefault:"30s"`
+	CacheTTL        time.Duration `env:"CACHE_TTL" envDefault:"5m"`
+	CacheStaleTTL   time.Duration `env:"CACHE_STALE_TTL" envDefault:"0"`
+
+	// List endpoints are cached only when their TTL is set.
+	CacheColaboradoresListTTL    time.Duration `env:"CACHE_COLABORADORES_LIST_TTL" envDefault:"0"`
+	CacheDepartamentosListTTL    time.Duration `env:"CACHE_DEPARTAMENTOS_LIST_TTL" envDefault:"0"`
+	CacheGerenteColaboradoresTTL time.Duration `env:"CACHE_GERENTE_COLABORADORES_TTL" envDefault:"0"`
+
+	CacheFailureThreshold int           `env:"CACHE_FAILURE_THRESHOLD" envDefault:"5"`
+	CacheOpenTimeout      time.Duration `env:"CACHE_OPEN_TIMEOUT" envDefault:"30s"`
+	CacheRetryAttempts    int           `env:"CACHE_RETRY_ATTEMPTS" envDefault:"3"`
+	CacheRetryBackoff     time.Duration `env:"CACHE_RETRY_BACKOFF" envDefault:"50ms"`
+
+	RequireIfMatch           bool          `env:"REQUIRE_IF_MATCH" envDefault:"false"`
+	PurgeRetentionDays       int           `env:"PURGE_RETENTION_DAYS" envDefault:"365"`
+	ConsistencyCheckInterval time.Duration `env:"CONSISTENCY_CHECK_INTERVAL" envDefault:"0"`
+
+	// AdminToken guards /admin; without it those routes are not served.
+	AdminToken string `env:"ADMIN_TOKEN"`
 }
 
 func LoadConfig() (*Config, error) {
@@ -19,5 +47,22 @@ func LoadConfig() (*Config, error) {
 	if err := env.Parse(&cfg); err != nil {
 		return nil, err
 	}
+
+	switch cfg.CacheBackend {
+	case "redis", "tiered":
+		if cfg.RedisHost == "" {
+			return nil, fmt.Errorf("REDIS_HOST is required when CACHE_BACKEND=%s", cfg.CacheBackend)
+		}
+		if cfg.CacheBackend == "tiered" && cfg.CacheLocalTTL <= 0 {
+			return nil, fmt.Errorf("CACHE_LOCAL_TTL must be positive when CACHE_BACKEND=tiered, got %s", cfg.CacheLocalTTL)
+		}
+	case "memory", "none":
+	default:
+		return nil, fmt.Errorf("invalid CACHE_BACKEND %q: use redis, memory, tiered or none", cfg.CacheBackend)
+	}
+
+	if cfg.PurgeRetentionDays < 1 {
+		return nil, fmt.Errorf("PURGE_RETENTION_DAYS must be at least 1, got %d", cfg.PurgeRetentionDays)
+	}
 	return &cfg, nil
 }
@@ -3,15 +3,21 @@ package database
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
 )
 
+// Cache stores JSON values by key. Set can attach tags naming what a value
+// was built from; InvalidateTags then drops every key carrying any of them,
+// so a change to one record reaches every cached value that embeds it.
 type Cache interface {
 	Get(ctx context.Context, key string, dest interface{}) error
-	Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error
+	Set(ctx context.Context, key string, value interface{}, ttl time.Duration, tags ...string) error
 	Delete(ctx context.Context, key string) error
+	InvalidateTags(ctx context.Context, tags ...string) error
 }
 
 type RedisCache struct {
@@ -25,6 +31,25 @@ func NewRedisCache(addr string) *RedisCache {
 	return &RedisCache{client: client}
 }
 
+// tagKey is the Redis set listing the keys tagged with tag.
+func tagKey(tag string) string {
+	return "tag:" + tag
+}
+
+// invalidateTags deletes the members of every tag set and the sets
+// themselves in one step, so a key tagged concurrently is either deleted or
+// stays tracked in a fresh set.
+var invalidateTags = redis.NewScript(`
+for _, tag in ipairs(KEYS) do
+	local members = redis.call('SMEMBERS', tag)
+	for _, key in ipairs(members) do
+		redis.call('DEL', key)
+	end
+	redis.call('DEL', tag)
+end
+return #KEYS
+`)
+
 func (r *RedisCache) Get(ctx context.Context, key string, dest interface{}) error {
 	val, err := r.client.Get(ctx, key).Result()
 	if err != nil {
@@ -33,14 +58,105 @@ func (r *RedisCache) Get(ctx context.Context, key string, dest interface{}) erro
 	return json.Unmarshal([]byte(val), dest)
 }
 
-func (r *RedisCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+// getWithTTL is Get that also returns how long the key has left, read in the
+// same transaction. It is negative when the key has no expiry.
+func (r *RedisCache) getWithTTL(ctx context.Context, key string, dest interface{}) (time.Duration, error) {
+	var get *redis.StringCmd
+	var pttl *redis.DurationCmd
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		get = pipe.Get(ctx, key)
+		pttl = pipe.PTTL(ctx, key)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	if err := json.Unmarshal([]byte(get.Val()), dest); err != nil {
+		return 0, err
+	}
+	return pttl.Val(), nil
+}
+
+// setTagged stores the value and records its key in every tag set. A tag set
+// must outlive all of its keys, or InvalidateTags would miss the older ones,
+// so its TTL only ever grows: a new set takes the key's TTL, an existing one
+// is extended when the key outlives it, and a key without TTL makes it
+// persistent.
+var setTagged = redis.NewScript(`
+local ttl = tonumber(ARGV[2])
+if ttl > 0 then
+	redis.call('SET', KEYS[1], ARGV[1], 'PX', ttl)
+else
+	redis.call('SET', KEYS[1], ARGV[1])
+end
+for i = 2, #KEYS do
+	local current = redis.call('PTTL', KEYS[i])
+	redis.call('SADD', KEYS[i], KEYS[1])
+	if ttl <= 0 then
+		redis.call('PERSIST', KEYS[i])
+	elseif current == -2 or (current >= 0 and current < ttl) then
+		redis.call('PEXPIRE', KEYS[i], ttl)
+	end
+end
+return #KEYS
+`)
+
+// Set stores value and records key in the set of each tag.
+func (r *RedisCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration, tags ...string) error {
 	data, err := json.Marshal(value)
 	if err != nil {
 		return err
 	}
-	return r.client.Set(ctx, key, data, ttl).Err()
+	if len(tags) == 0 {
+		return r.client.Set(ctx, key, data, ttl).Err()
+	}
+
+	keys := make([]string, 0, len(tags)+1)
+	keys = append(keys, key)
+	for _, tag := range tags {
+		keys = append(keys, tagKey(tag))
+	}
+	return setTagged.Run(ctx, r.client, keys, data, ttl.Milliseconds()).Err()
 }
 
 func (r *RedisCache) Delete(ctx context.Context, key string) error {
 	return r.client.Del(ctx, key).Err()
-}
\ No newline at end of file
+}
+
+func (r *RedisCache) InvalidateTags(ctx context.Context, tags ...string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		keys = append(keys, tagKey(tag))
+	}
+	return invalidateTags.Run(ctx, r.client, keys).Err()
+}
+
+// CacheOptions selects and sizes the Cache built by NewCache.
+type CacheOptions struct {
+	Backend    string
+	RedisAddr  string
+	MaxEntries int
+	LocalTTL   time.Duration
+}
+
+// NewCache builds the backend named in opts: redis, memory, tiered (memory
+// in front of redis) or none.
+func NewCache(opts CacheOptions, logger *zap.Logger) (Cache, error) {
+	switch opts.Backend {
+	case "redis":
+		return NewRedisCache(opts.RedisAddr), nil
+	case "memory":
+		return NewMemoryCache(opts.MaxEntries), nil
+	case "tiered":
+		if opts.LocalTTL <= 0 {
+			return nil, fmt.Errorf("tiered cache needs a positive local TTL, got %s", opts.LocalTTL)
+		}
+		return NewTieredCache(NewMemoryCache(opts.MaxEntries), NewRedisCache(opts.RedisAddr), opts.LocalTTL, logger), nil
+	case "none":
+		return NewNoopCache(), nil
+	}
+	return nil, fmt.Errorf("unknown cache backend %q", opts.Backend)
+}
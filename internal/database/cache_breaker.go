@@ -0,0 +1,372 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// ErrCircuitOpen is returned while the breaker is short-circuiting calls to
+// the wrapped cache.
+var ErrCircuitOpen = errors.New("cache circuit open")
+
+type BreakerState int
+
+const (
+	BreakerClosed BreakerState = iota
+	BreakerHalfOpen
+	BreakerOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerHalfOpen:
+		return "half_open"
+	case BreakerOpen:
+		return "open"
+	}
+	return "closed"
+}
+
+// flushInterval is how often pending invalidations are retried in the
+// background.
+const flushInterval = time.Second
+
+var (
+	cacheCircuitState = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "cache_circuit_state",
+			Help: "Cache circuit breaker state (0 closed, 1 half-open, 2 open)",
+		},
+	)
+
+	cacheErrorsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cache_errors_total",
+			Help: "Total number of failed cache calls",
+		},
+		[]string{"operation"},
+	)
+
+	cacheShortCircuitedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cache_short_circuited_total",
+			Help: "Total number of cache calls skipped because the circuit was open",
+		},
+		[]string{"operation"},
+	)
+
+	cachePendingInvalidations = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "cache_pending_invalidations",
+			Help: "Keys and tags whose invalidation failed and is waiting to be retried",
+		},
+	)
+)
+
+type BreakerOptions struct {
+	// FailureThreshold consecutive failures open the circuit.
+	FailureThreshold int
+	// OpenTimeout is how long the circuit stays open before a probe call.
+	OpenTimeout time.Duration
+	// RetryAttempts is how many times deletes and invalidations are tried
+	// before giving up, waiting RetryBackoff, then twice that, and so on.
+	RetryAttempts int
+	RetryBackoff  time.Duration
+}
+
+// CacheHealth is the breaker state reported by /health.
+type CacheHealth struct {
+	State                string `json:"state"`
+	PendingInvalidations int    `json:"pending_invalidations"`
+}
+
+// BreakerCache guards a Cache with a circuit breaker so an unreachable
+// backend costs one failed call per OpenTimeout instead of a timeout per
+// request. Reads and fills are tried once and fail open, since losing one
+// only costs a database read. Invalidations that fail are kept and retried
+// by Run until they succeed; while any is pending, Get reports a miss so
+// values that should have been dropped are never served. Pending
+// invalidations live in this process only: other instances, or this one
+// after a restart, rely on the entries' TTL.
+type BreakerCache struct {
+	inner  Cache
+	opts   BreakerOptions
+	logger *zap.Logger
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+
+	pendingMu   sync.Mutex
+	pendingKeys map[string]struct{}
+	pendingTags map[string]struct{}
+}
+
+func NewBreakerCache(inner Cache, opts BreakerOptions, logger *zap.Logger) *BreakerCache {
+	return &BreakerCache{
+		inner:       inner,
+		opts:        opts,
+		logger:      logger,
+		pendingKeys: make(map[string]struct{}),
+		pendingTags: make(map[string]struct{}),
+	}
+}
+
+// Get is tried once: a failed read just falls back to the database. It
+// never flushes pending invalidations itself, which would put their retries
+// on the request path; Run does that.
+func (b *BreakerCache) Get(ctx context.Context, key string, dest interface{}) error {
+	if b.pendingCount() > 0 {
+		return ErrCacheMiss
+	}
+	return b.call(ctx, "get", func() error {
+		return b.inner.Get(ctx, key, dest)
+	})
+}
+
+// Set is tried once as well: a value that failed to be cached is simply
+// loaded again on the next read.
+func (b *BreakerCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration, tags ...string) error {
+	return b.call(ctx, "set", func() error {
+		return b.inner.Set(ctx, key, value, ttl, tags...)
+	})
+}
+
+func (b *BreakerCache) Delete(ctx context.Context, key string) error {
+	err := b.retry(ctx, "delete", func() error {
+		return b.inner.Delete(ctx, key)
+	})
+	if err != nil {
+		b.addPending([]string{key}, nil)
+	}
+	return err
+}
+
+func (b *BreakerCache) InvalidateTags(ctx context.Context, tags ...string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	err := b.retry(ctx, "invalidate", func() error {
+		return b.inner.InvalidateTags(ctx, tags...)
+	})
+	if err != nil {
+		b.addPending(nil, tags)
+	}
+	return err
+}
+
+// Health reports the circuit state and how many invalidations are pending.
+func (b *BreakerCache) Health() CacheHealth {
+	b.mu.Lock()
+	state := b.state
+	b.mu.Unlock()
+	return CacheHealth{State: state.String(), PendingInvalidations: b.pendingCount()}
+}
+
+// Run retries pending invalidations until ctx is done.
+func (b *BreakerCache) Run(ctx context.Context) {
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if b.pendingCount() > 0 {
+				b.flush(ctx)
+			}
+		}
+	}
+}
+
+// flush retries every pending invalidation once. Entries are only removed
+// after the backend confirmed them, so a concurrent Get keeps bypassing the
+// cache until then.
+func (b *BreakerCache) flush(ctx context.Context) {
+	b.pendingMu.Lock()
+	keys := setKeys(b.pendingKeys)
+	tags := setKeys(b.pendingTags)
+	b.pendingMu.Unlock()
+
+	if len(tags) > 0 {
+		err := b.call(ctx, "invalidate", func() error {
+			return b.inner.InvalidateTags(ctx, tags...)
+		})
+		if err != nil {
+			return
+		}
+		b.pendingMu.Lock()
+		for _, tag := range tags {
+			delete(b.pendingTags, tag)
+		}
+		b.pendingMu.Unlock()
+	}
+
+	for _, key := range keys {
+		err := b.call(ctx, "delete", func() error {
+			return b.inner.Delete(ctx, key)
+		})
+		if err != nil {
+			return
+		}
+		b.pendingMu.Lock()
+		delete(b.pendingKeys, key)
+		b.pendingMu.Unlock()
+	}
+
+	b.updatePendingGauge()
+	if len(keys)+len(tags) > 0 {
+		b.logger.Info("Pending cache invalidations applied", zap.Int("keys", len(keys)), zap.Int("tags", len(tags)))
+	}
+}
+
+func (b *BreakerCache) addPending(keys, tags []string) {
+	b.pendingMu.Lock()
+	for _, key := range keys {
+		b.pendingKeys[key] = struct{}{}
+	}
+	for _, tag := range tags {
+		b.pendingTags[tag] = struct{}{}
+	}
+	b.pendingMu.Unlock()
+
+	b.updatePendingGauge()
+	b.logger.Warn("Cache invalidation deferred", zap.Strings("keys", keys), zap.Strings("tags", tags))
+}
+
+func (b *BreakerCache) pendingCount() int {
+	b.pendingMu.Lock()
+	defer b.pendingMu.Unlock()
+	return len(b.pendingKeys) + len(b.pendingTags)
+}
+
+func (b *BreakerCache) updatePendingGauge() {
+	cachePendingInvalidations.Set(float64(b.pendingCount()))
+}
+
+// retry runs op up to RetryAttempts times with exponential backoff and
+// jitter, stopping early when the circuit opens or ctx is done.
+func (b *BreakerCache) retry(ctx context.Context, operation string, op func() error) error {
+	backoff := b.opts.RetryBackoff
+	var err error
+	for attempt := 0; attempt < max(b.opts.RetryAttempts, 1); attempt++ {
+		if attempt > 0 {
+			wait := backoff + rand.N(backoff/2+1)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(wait):
+			}
+			backoff *= 2
+		}
+
+		err = b.call(ctx, operation, op)
+		if err == nil || errors.Is(err, ErrCircuitOpen) {
+			return err
+		}
+	}
+	return err
+}
+
+// call runs op if the circuit allows it and records the outcome. Misses are
+// successful calls; a canceled request says nothing about the backend.
+func (b *BreakerCache) call(ctx context.Context, operation string, op func() error) error {
+	if !b.allow() {
+		cacheShortCircuitedTotal.WithLabelValues(operation).Inc()
+		return ErrCircuitOpen
+	}
+
+	err := op()
+	switch {
+	case err == nil || isCacheMiss(err):
+		b.record(true)
+	case ctx.Err() != nil:
+		b.release()
+	default:
+		cacheErrorsTotal.WithLabelValues(operation).Inc()
+		b.record(false)
+	}
+	return err
+}
+
+func isCacheMiss(err error) bool {
+	return errors.Is(err, ErrCacheMiss) || errors.Is(err, redis.Nil)
+}
+
+// allow lets every call through while closed and a single probe once the
+// open timeout has elapsed.
+func (b *BreakerCache) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerOpen && time.Since(b.openedAt) >= b.opts.OpenTimeout {
+		b.setState(BreakerHalfOpen)
+	}
+	switch b.state {
+	case BreakerClosed:
+		return true
+	case BreakerHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	}
+	return false
+}
+
+func (b *BreakerCache) record(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if success {
+		b.failures = 0
+		if b.state != BreakerClosed {
+			b.logger.Info("Cache circuit closed")
+			b.setState(BreakerClosed)
+		}
+		return
+	}
+
+	b.failures++
+	if b.state == BreakerHalfOpen || b.failures >= b.opts.FailureThreshold {
+		if b.state != BreakerOpen {
+			b.logger.Warn("Cache circuit opened", zap.Int("failures", b.failures))
+		}
+		b.openedAt = time.Now()
+		b.setState(BreakerOpen)
+	}
+}
+
+// release frees the half-open probe without counting a result.
+func (b *BreakerCache) release() {
+	b.mu.Lock()
+	b.probing = false
+	b.mu.Unlock()
+}
+
+// setState is called with mu held.
+func (b *BreakerCache) setState(state BreakerState) {
+	b.state = state
+	cacheCircuitState.Set(float64(state))
+}
+
+func setKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	return keys
+}
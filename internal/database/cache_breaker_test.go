@@ -0,0 +1,332 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var errBackendDown = errors.New("backend down")
+
+// fakeCache is an in-memory Cache whose calls fail with err while it is set.
+type fakeCache struct {
+	mu     sync.Mutex
+	err    error
+	values map[string][]byte
+	tags   map[string][]string
+	calls  map[string]int
+}
+
+func newFakeCache() *fakeCache {
+	return &fakeCache{
+		values: make(map[string][]byte),
+		tags:   make(map[string][]string),
+		calls:  make(map[string]int),
+	}
+}
+
+func (f *fakeCache) fail(err error) {
+	f.mu.Lock()
+	f.err = err
+	f.mu.Unlock()
+}
+
+func (f *fakeCache) callCount(operation string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[operation]
+}
+
+func (f *fakeCache) Get(_ context.Context, key string, dest interface{}) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls["get"]++
+	if f.err != nil {
+		return f.err
+	}
+	data, ok := f.values[key]
+	if !ok {
+		return ErrCacheMiss
+	}
+	return json.Unmarshal(data, dest)
+}
+
+func (f *fakeCache) Set(_ context.Context, key string, value interface{}, _ time.Duration, tags ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls["set"]++
+	if f.err != nil {
+		return f.err
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	f.values[key] = data
+	for _, tag := range tags {
+		f.tags[tag] = append(f.tags[tag], key)
+	}
+	return nil
+}
+
+func (f *fakeCache) Delete(_ context.Context, key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls["delete"]++
+	if f.err != nil {
+		return f.err
+	}
+	delete(f.values, key)
+	return nil
+}
+
+func (f *fakeCache) InvalidateTags(_ context.Context, tags ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls["invalidate"]++
+	if f.err != nil {
+		return f.err
+	}
+	for _, tag := range tags {
+		for _, key := range f.tags[tag] {
+			delete(f.values, key)
+		}
+		delete(f.tags, tag)
+	}
+	return nil
+}
+
+func newTestBreaker(inner Cache, threshold int) *BreakerCache {
+	return NewBreakerCache(inner, BreakerOptions{
+		FailureThreshold: threshold,
+		OpenTimeout:      time.Minute,
+		RetryAttempts:    1,
+	}, zap.NewNop())
+}
+
+// elapseOpenTimeout makes the open circuit due for a probe.
+func elapseOpenTimeout(b *BreakerCache) {
+	b.mu.Lock()
+	b.openedAt = time.Now().Add(-b.opts.OpenTimeout)
+	b.mu.Unlock()
+}
+
+func TestBreakerStateTransitions(t *testing.T) {
+	type step struct {
+		backendErr error
+		elapse     bool
+		wantErr    error
+		wantState  BreakerState
+		// wantCalls is how many calls reached the backend so far.
+		wantCalls int
+	}
+
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "opens after threshold consecutive failures",
+			steps: []step{
+				{backendErr: errBackendDown, wantErr: errBackendDown, wantState: BreakerClosed, wantCalls: 1},
+				{backendErr: errBackendDown, wantErr: errBackendDown, wantState: BreakerClosed, wantCalls: 2},
+				{backendErr: errBackendDown, wantErr: errBackendDown, wantState: BreakerOpen, wantCalls: 3},
+				{wantErr: ErrCircuitOpen, wantState: BreakerOpen, wantCalls: 3},
+			},
+		},
+		{
+			name: "success resets the failure count",
+			steps: []step{
+				{backendErr: errBackendDown, wantErr: errBackendDown, wantState: BreakerClosed, wantCalls: 1},
+				{backendErr: errBackendDown, wantErr: errBackendDown, wantState: BreakerClosed, wantCalls: 2},
+				{wantState: BreakerClosed, wantCalls: 3},
+				{backendErr: errBackendDown, wantErr: errBackendDown, wantState: BreakerClosed, wantCalls: 4},
+				{backendErr: errBackendDown, wantErr: errBackendDown, wantState: BreakerClosed, wantCalls: 5},
+			},
+		},
+		{
+			name: "successful probe closes the circuit",
+			steps: []step{
+				{backendErr: errBackendDown, wantErr: errBackendDown, wantCalls: 1},
+				{backendErr: errBackendDown, wantErr: errBackendDown, wantCalls: 2},
+				{backendErr: errBackendDown, wantErr: errBackendDown, wantState: BreakerOpen, wantCalls: 3},
+				{elapse: true, wantState: BreakerClosed, wantCalls: 4},
+				{wantState: BreakerClosed, wantCalls: 5},
+			},
+		},
+		{
+			name: "failed probe opens the circuit again",
+			steps: []step{
+				{backendErr: errBackendDown, wantErr: errBackendDown, wantCalls: 1},
+				{backendErr: errBackendDown, wantErr: errBackendDown, wantCalls: 2},
+				{backendErr: errBackendDown, wantErr: errBackendDown, wantState: BreakerOpen, wantCalls: 3},
+				{elapse: true, backendErr: errBackendDown, wantErr: errBackendDown, wantState: BreakerOpen, wantCalls: 4},
+				{wantErr: ErrCircuitOpen, wantState: BreakerOpen, wantCalls: 4},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			inner := newFakeCache()
+			b := newTestBreaker(inner, 3)
+
+			for i, s := range tt.steps {
+				inner.fail(s.backendErr)
+				if s.elapse {
+					elapseOpenTimeout(b)
+				}
+				err := b.Set(ctx, "k", "v", time.Minute)
+				if !errors.Is(err, s.wantErr) {
+					t.Fatalf("step %d: err = %v, want %v", i, err, s.wantErr)
+				}
+				if b.state != s.wantState {
+					t.Fatalf("step %d: state = %s, want %s", i, b.state, s.wantState)
+				}
+				if got := inner.callCount("set"); got != s.wantCalls {
+					t.Fatalf("step %d: backend calls = %d, want %d", i, got, s.wantCalls)
+				}
+			}
+		})
+	}
+}
+
+func TestBreakerHalfOpenAllowsOneProbe(t *testing.T) {
+	b := newTestBreaker(newFakeCache(), 1)
+	b.record(false)
+	if b.state != BreakerOpen {
+		t.Fatalf("state = %s, want %s", b.state, BreakerOpen)
+	}
+	if b.allow() {
+		t.Fatal("open circuit allowed a call before the open timeout")
+	}
+
+	elapseOpenTimeout(b)
+	if !b.allow() {
+		t.Fatal("half-open circuit refused the probe")
+	}
+	if b.state != BreakerHalfOpen {
+		t.Fatalf("state = %s, want %s", b.state, BreakerHalfOpen)
+	}
+	if b.allow() {
+		t.Fatal("half-open circuit allowed a second call while the probe is in flight")
+	}
+
+	b.record(true)
+	if b.state != BreakerClosed {
+		t.Fatalf("state = %s, want %s", b.state, BreakerClosed)
+	}
+	if !b.allow() || !b.allow() {
+		t.Fatal("closed circuit refused a call")
+	}
+}
+
+func TestBreakerPendingInvalidations(t *testing.T) {
+	tests := []struct {
+		name       string
+		invalidate func(ctx context.Context, b *BreakerCache) error
+		operation  string
+	}{
+		{
+			name: "tag",
+			invalidate: func(ctx context.Context, b *BreakerCache) error {
+				return b.InvalidateTags(ctx, "t")
+			},
+			operation: "invalidate",
+		},
+		{
+			name: "key",
+			invalidate: func(ctx context.Context, b *BreakerCache) error {
+				return b.Delete(ctx, "k")
+			},
+			operation: "delete",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			inner := newFakeCache()
+			b := newTestBreaker(inner, 100)
+			if err := b.Set(ctx, "k", "v", time.Minute, "t"); err != nil {
+				t.Fatalf("set: %v", err)
+			}
+
+			inner.fail(errBackendDown)
+			if err := tt.invalidate(ctx, b); !errors.Is(err, errBackendDown) {
+				t.Fatalf("invalidate: err = %v, want %v", err, errBackendDown)
+			}
+			if got := b.Health().PendingInvalidations; got != 1 {
+				t.Fatalf("pending = %d, want 1", got)
+			}
+
+			// The backend is back but still holds the stale value: Get must
+			// not reach it until the invalidation is applied.
+			inner.fail(nil)
+			var got string
+			if err := b.Get(ctx, "k", &got); !errors.Is(err, ErrCacheMiss) {
+				t.Fatalf("get while pending: err = %v, want %v", err, ErrCacheMiss)
+			}
+			if inner.callCount("get") != 0 {
+				t.Fatal("get reached the backend while an invalidation was pending")
+			}
+
+			inner.fail(errBackendDown)
+			calls := inner.callCount(tt.operation)
+			b.flush(ctx)
+			if inner.callCount(tt.operation) != calls+1 {
+				t.Fatal("flush did not retry the pending invalidation")
+			}
+			if got := b.Health().PendingInvalidations; got != 1 {
+				t.Fatalf("pending after failed flush = %d, want 1", got)
+			}
+
+			inner.fail(nil)
+			b.flush(ctx)
+			if got := b.Health().PendingInvalidations; got != 0 {
+				t.Fatalf("pending after flush = %d, want 0", got)
+			}
+			if err := b.Get(ctx, "k", &got); !errors.Is(err, ErrCacheMiss) {
+				t.Fatalf("get after flush: err = %v, want the invalidated key to miss", err)
+			}
+			if inner.callCount("get") != 1 {
+				t.Fatal("get did not reach the backend once nothing was pending")
+			}
+		})
+	}
+}
+
+func TestBreakerFlushSkipsWhileOpen(t *testing.T) {
+	ctx := context.Background()
+	inner := newFakeCache()
+	b := newTestBreaker(inner, 1)
+
+	inner.fail(errBackendDown)
+	if err := b.InvalidateTags(ctx, "t"); !errors.Is(err, errBackendDown) {
+		t.Fatalf("invalidate: err = %v, want %v", err, errBackendDown)
+	}
+	if b.state != BreakerOpen {
+		t.Fatalf("state = %s, want %s", b.state, BreakerOpen)
+	}
+
+	inner.fail(nil)
+	b.flush(ctx)
+	if inner.callCount("invalidate") != 1 {
+		t.Fatal("flush reached the backend while the circuit was open")
+	}
+	if got := b.Health().PendingInvalidations; got != 1 {
+		t.Fatalf("pending = %d, want 1", got)
+	}
+
+	elapseOpenTimeout(b)
+	b.flush(ctx)
+	if got := b.Health(); got.PendingInvalidations != 0 || got.State != BreakerClosed.String() {
+		t.Fatalf("health = %+v, want closed with nothing pending", got)
+	}
+}
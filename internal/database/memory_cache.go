@@ -0,0 +1,169 @@
+package database
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCacheMiss is returned by the in-process caches when a key is absent or
+// expired.
+var ErrCacheMiss = errors.New("cache miss")
+
+type memoryEntry struct {
+	key       string
+	data      []byte
+	expiresAt time.Time
+	tags      []string
+}
+
+// MemoryCache is an in-process LRU cache. Values are kept as JSON, like in
+// Redis, so callers never share mutable state with the cache. When full, the
+// least recently used entry is evicted; expired entries are dropped lazily
+// on access or eviction.
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	order      *list.List
+	entries    map[string]*list.Element
+	tags       map[string]map[string]struct{}
+}
+
+// NewMemoryCache creates a cache holding at most maxEntries values; zero or
+// less means unbounded.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+		tags:       make(map[string]map[string]struct{}),
+	}
+}
+
+func (m *MemoryCache) Get(ctx context.Context, key string, dest interface{}) error {
+	m.mu.Lock()
+	el, ok := m.entries[key]
+	if !ok {
+		m.mu.Unlock()
+		return ErrCacheMiss
+	}
+	entry := el.Value.(*memoryEntry)
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		m.remove(el)
+		m.mu.Unlock()
+		return ErrCacheMiss
+	}
+	m.order.MoveToFront(el)
+	data := entry.data
+	m.mu.Unlock()
+
+	return json.Unmarshal(data, dest)
+}
+
+// Set stores value for ttl; zero or less means it never expires.
+func (m *MemoryCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration, tags ...string) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	entry := &memoryEntry{key: key, data: data, tags: tags}
+	if ttl > 0 {
+		entry.expiresAt = time.Now().Add(ttl)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.entries[key]; ok {
+		m.remove(el)
+	}
+	m.entries[key] = m.order.PushFront(entry)
+	for _, tag := range tags {
+		keys, ok := m.tags[tag]
+		if !ok {
+			keys = make(map[string]struct{})
+			m.tags[tag] = keys
+		}
+		keys[key] = struct{}{}
+	}
+
+	for m.maxEntries > 0 && m.order.Len() > m.maxEntries {
+		m.remove(m.order.Back())
+	}
+	return nil
+}
+
+func (m *MemoryCache) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.entries[key]; ok {
+		m.remove(el)
+	}
+	return nil
+}
+
+func (m *MemoryCache) InvalidateTags(ctx context.Context, tags ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, tag := range tags {
+		for key := range m.tags[tag] {
+			if el, ok := m.entries[key]; ok {
+				m.remove(el)
+			}
+		}
+		delete(m.tags, tag)
+	}
+	return nil
+}
+
+// Len reports how many entries are stored, expired ones included.
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.order.Len()
+}
+
+// remove drops an entry and its tag references. The caller holds mu.
+func (m *MemoryCache) remove(el *list.Element) {
+	entry := el.Value.(*memoryEntry)
+	m.order.Remove(el)
+	delete(m.entries, entry.key)
+	for _, tag := range entry.tags {
+		if keys, ok := m.tags[tag]; ok {
+			delete(keys, entry.key)
+			if len(keys) == 0 {
+				delete(m.tags, tag)
+			}
+		}
+	}
+}
+
+// NoopCache stores nothing: every Get misses. It lets the API run without
+// any cache at all.
+type NoopCache struct{}
+
+func NewNoopCache() NoopCache {
+	return NoopCache{}
+}
+
+func (NoopCache) Get(ctx context.Context, key string, dest interface{}) error {
+	return ErrCacheMiss
+}
+
+func (NoopCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration, tags ...string) error {
+	return nil
+}
+
+func (NoopCache) Delete(ctx context.Context, key string) error {
+	return nil
+}
+
+func (NoopCache) InvalidateTags(ctx context.Context, tags ...string) error {
+	return nil
+}
@@ -0,0 +1,131 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMemoryCacheEviction(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxEntries int
+		// touch is read between the sets, making it recently used.
+		touch     string
+		wantKept  []string
+		wantGone  []string
+		wantCount int
+	}{
+		{name: "evicts the oldest", maxEntries: 2, wantKept: []string{"b", "c"}, wantGone: []string{"a"}, wantCount: 2},
+		{name: "a read refreshes recency", maxEntries: 2, touch: "a", wantKept: []string{"a", "c"}, wantGone: []string{"b"}, wantCount: 2},
+		{name: "unbounded", maxEntries: 0, wantKept: []string{"a", "b", "c"}, wantCount: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			m := NewMemoryCache(tt.maxEntries)
+			m.Set(ctx, "a", 1, 0)
+			m.Set(ctx, "b", 2, 0)
+			if tt.touch != "" {
+				var v int
+				if err := m.Get(ctx, tt.touch, &v); err != nil {
+					t.Fatalf("get %s: %v", tt.touch, err)
+				}
+			}
+			m.Set(ctx, "c", 3, 0)
+
+			var v int
+			for _, key := range tt.wantKept {
+				if err := m.Get(ctx, key, &v); err != nil {
+					t.Fatalf("get %s: %v, want kept", key, err)
+				}
+			}
+			for _, key := range tt.wantGone {
+				if err := m.Get(ctx, key, &v); !errors.Is(err, ErrCacheMiss) {
+					t.Fatalf("get %s: err = %v, want evicted", key, err)
+				}
+			}
+			if m.Len() != tt.wantCount {
+				t.Fatalf("len = %d, want %d", m.Len(), tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestMemoryCacheExpiry(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemoryCache(0)
+	m.Set(ctx, "short", "v", 10*time.Millisecond)
+	m.Set(ctx, "long", "v", time.Hour)
+	m.Set(ctx, "forever", "v", 0)
+
+	time.Sleep(20 * time.Millisecond)
+
+	var v string
+	if err := m.Get(ctx, "short", &v); !errors.Is(err, ErrCacheMiss) {
+		t.Fatalf("expired key: err = %v, want %v", err, ErrCacheMiss)
+	}
+	for _, key := range []string{"long", "forever"} {
+		if err := m.Get(ctx, key, &v); err != nil {
+			t.Fatalf("get %s: %v", key, err)
+		}
+	}
+	if m.Len() != 2 {
+		t.Fatalf("len = %d, want the expired entry dropped on access", m.Len())
+	}
+}
+
+func TestMemoryCacheInvalidateTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		tags     []string
+		wantKept []string
+	}{
+		{name: "one tag", tags: []string{"departamento:1"}, wantKept: []string{"c"}},
+		{name: "several tags", tags: []string{"departamento:1", "colaborador:2"}, wantKept: nil},
+		{name: "unknown tag", tags: []string{"departamento:9"}, wantKept: []string{"a", "b", "c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			m := NewMemoryCache(0)
+			m.Set(ctx, "a", 1, 0, "departamento:1")
+			m.Set(ctx, "b", 2, 0, "departamento:1", "colaborador:2")
+			m.Set(ctx, "c", 3, 0, "colaborador:2")
+
+			m.InvalidateTags(ctx, tt.tags...)
+
+			kept := map[string]bool{}
+			for _, key := range tt.wantKept {
+				kept[key] = true
+			}
+			var v int
+			for _, key := range []string{"a", "b", "c"} {
+				err := m.Get(ctx, key, &v)
+				if kept[key] && err != nil {
+					t.Fatalf("get %s: %v, want kept", key, err)
+				}
+				if !kept[key] && !errors.Is(err, ErrCacheMiss) {
+					t.Fatalf("get %s: err = %v, want invalidated", key, err)
+				}
+			}
+		})
+	}
+}
+
+func TestMemoryCacheOverwriteDropsOldTags(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemoryCache(0)
+	m.Set(ctx, "a", 1, 0, "old")
+	m.Set(ctx, "a", 2, 0, "new")
+
+	m.InvalidateTags(ctx, "old")
+
+	var v int
+	if err := m.Get(ctx, "a", &v); err != nil || v != 2 {
+		t.Fatalf("get = %d, %v; want 2 still cached", v, err)
+	}
+}
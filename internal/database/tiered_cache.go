@@ -0,0 +1,152 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// invalidationChannel carries deletes and tag invalidations between the
+// instances sharing a Redis, so each can drop them from its local tier.
+const invalidationChannel = "cache:invalidate"
+
+type invalidation struct {
+	Origin string   `json:"origin"`
+	Keys   []string `json:"keys,omitempty"`
+	Tags   []string `json:"tags,omitempty"`
+}
+
+// tieredValue is what TieredCache stores in Redis: the value plus its tags,
+// so an instance filling its local tier from Redis can tag the entry too.
+// Every instance sharing the Redis must therefore use the tiered backend.
+type tieredValue struct {
+	Tags  []string        `json:"tags,omitempty"`
+	Value json.RawMessage `json:"value"`
+}
+
+// TieredCache reads through an in-process L1 to Redis as L2. Writes go to
+// both tiers and invalidations are published so other instances evict their
+// L1 copies. A message lost while the subscription reconnects leaves an L1
+// entry stale for at most localTTL.
+type TieredCache struct {
+	local    *MemoryCache
+	remote   *RedisCache
+	localTTL time.Duration
+	origin   string
+	logger   *zap.Logger
+
+	pubsub   *redis.PubSub
+	messages <-chan *redis.Message
+	done     chan struct{}
+}
+
+// NewTieredCache subscribes to the invalidation channel; Close stops it.
+func NewTieredCache(local *MemoryCache, remote *RedisCache, localTTL time.Duration, logger *zap.Logger) *TieredCache {
+	t := &TieredCache{
+		local:    local,
+		remote:   remote,
+		localTTL: localTTL,
+		origin:   uuid.NewString(),
+		logger:   logger,
+		pubsub:   remote.client.Subscribe(context.Background(), invalidationChannel),
+		done:     make(chan struct{}),
+	}
+	t.messages = t.pubsub.Channel()
+	go t.listen()
+	return t
+}
+
+func (t *TieredCache) Get(ctx context.Context, key string, dest interface{}) error {
+	if err := t.local.Get(ctx, key, dest); err == nil {
+		return nil
+	}
+
+	var stored tieredValue
+	remaining, err := t.remote.getWithTTL(ctx, key, &stored)
+	if err != nil {
+		return err
+	}
+	t.local.Set(ctx, key, stored.Value, t.localLifetime(remaining), stored.Tags...)
+	return json.Unmarshal(stored.Value, dest)
+}
+
+func (t *TieredCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration, tags ...string) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if err := t.remote.Set(ctx, key, tieredValue{Tags: tags, Value: data}, ttl, tags...); err != nil {
+		return err
+	}
+	return t.local.Set(ctx, key, json.RawMessage(data), t.localLifetime(ttl), tags...)
+}
+
+func (t *TieredCache) Delete(ctx context.Context, key string) error {
+	t.local.Delete(ctx, key)
+	if err := t.remote.Delete(ctx, key); err != nil {
+		return err
+	}
+	return t.publish(ctx, invalidation{Keys: []string{key}})
+}
+
+func (t *TieredCache) InvalidateTags(ctx context.Context, tags ...string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	t.local.InvalidateTags(ctx, tags...)
+	if err := t.remote.InvalidateTags(ctx, tags...); err != nil {
+		return err
+	}
+	return t.publish(ctx, invalidation{Tags: tags})
+}
+
+// Close stops listening for invalidations from other instances.
+func (t *TieredCache) Close() error {
+	err := t.pubsub.Close()
+	<-t.done
+	return err
+}
+
+// localLifetime caps how long L1 keeps a value, as L1 copies are only as
+// fresh as the last invalidation this instance received. ttl is what the
+// value has in Redis, or what is left of it on a fill, so an L1 copy never
+// outlives the L2 entry; a ttl of zero or less means no expiry there.
+func (t *TieredCache) localLifetime(ttl time.Duration) time.Duration {
+	if ttl <= 0 || ttl > t.localTTL {
+		return t.localTTL
+	}
+	return ttl
+}
+
+func (t *TieredCache) publish(ctx context.Context, msg invalidation) error {
+	msg.Origin = t.origin
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return t.remote.client.Publish(ctx, invalidationChannel, data).Err()
+}
+
+func (t *TieredCache) listen() {
+	defer close(t.done)
+
+	ctx := context.Background()
+	for message := range t.messages {
+		var msg invalidation
+		if err := json.Unmarshal([]byte(message.Payload), &msg); err != nil {
+			t.logger.Warn("Ignoring malformed cache invalidation", zap.Error(err))
+			continue
+		}
+		if msg.Origin == t.origin {
+			continue
+		}
+		for _, key := range msg.Keys {
+			t.local.Delete(ctx, key)
+		}
+		t.local.InvalidateTags(ctx, msg.Tags...)
+	}
+}
@@ -0,0 +1,30 @@
+package database
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTieredCacheLocalLifetime(t *testing.T) {
+	cache := &TieredCache{localTTL: 30 * time.Second}
+
+	tests := []struct {
+		name string
+		ttl  time.Duration
+		want time.Duration
+	}{
+		{name: "redis ttl longer than local ttl", ttl: 5 * time.Minute, want: 30 * time.Second},
+		{name: "redis ttl shorter than local ttl", ttl: 4 * time.Second, want: 4 * time.Second},
+		{name: "redis ttl equal to local ttl", ttl: 30 * time.Second, want: 30 * time.Second},
+		{name: "no expiry in redis", ttl: -1, want: 30 * time.Second},
+		{name: "zero ttl", ttl: 0, want: 30 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cache.localLifetime(tt.ttl); got != tt.want {
+				t.Fatalf("localLifetime(%v) = %v, want %v", tt.ttl, got, tt.want)
+			}
+		})
+	}
+}
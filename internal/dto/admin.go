@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PurgeRequest can only lengthen the retention: RetentionDays below
+// PURGE_RETENTION_DAYS is rejected.
+type PurgeRequest struct {
+	RetentionDays *int `json:"retention_days" binding:"omitempty,min=1"`
+}
+
+type PurgeResponse struct {
+	DeletedBefore time.Time `json:"deleted_before"`
+	Colaboradores int64     `json:"colaboradores"`
+	Departamentos int64     `json:"departamentos"`
+}
+
+// GerenteViolation is a departamento breaking the rule that its gerente must
+// be an active colaborador of the departamento itself.
+type GerenteViolation struct {
+	DepartamentoID        uuid.UUID  `json:"departamento_id"`
+	DepartamentoNome      string     `json:"departamento_nome"`
+	GerenteID             *uuid.UUID `json:"gerente_id"`
+	GerenteDepartamentoID *uuid.UUID `json:"gerente_departamento_id,omitempty"`
+	Reason                string     `json:"reason"`
+}
+
+type ConsistencyReport struct {
+	CheckedAt  time.Time          `json:"checked_at"`
+	Total      int                `json:"total"`
+	Violations []GerenteViolation `json:"violations"`
+}
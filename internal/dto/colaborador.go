@@ -21,6 +21,33 @@ type UpdateColaboradorRequest struct {
 	DepartamentoID *uuid.UUID `json:"departamento_id"`
 }
 
+// PatchColaboradorRequest is a JSON Merge Patch: absent members are left
+// untouched and null clears the field (only rg may be cleared).
+type PatchColaboradorRequest struct {
+	Nome           Optional[string]    `json:"nome" swaggertype:"string"`
+	CPF            Optional[string]    `json:"cpf" swaggertype:"string"`
+	RG             Optional[string]    `json:"rg" swaggertype:"string"`
+	DepartamentoID Optional[uuid.UUID] `json:"departamento_id" swaggertype:"string"`
+}
+
+// ToPatch expresses PUT semantics as a patch: empty values are ignored.
+func (r *UpdateColaboradorRequest) ToPatch() *PatchColaboradorRequest {
+	patch := &PatchColaboradorRequest{}
+	if r.Nome != "" {
+		patch.Nome = Some(r.Nome)
+	}
+	if r.CPF != "" {
+		patch.CPF = Some(r.CPF)
+	}
+	if r.RG != nil && *r.RG != "" {
+		patch.RG = Some(*r.RG)
+	}
+	if r.DepartamentoID != nil {
+		patch.DepartamentoID = Some(*r.DepartamentoID)
+	}
+	return patch
+}
+
 type ColaboradorResponse struct {
 	ID             uuid.UUID `json:"id"`
 	Nome           string    `json:"nome"`
@@ -28,6 +55,7 @@ type ColaboradorResponse struct {
 	RG             *string   `json:"rg,omitempty"`
 	DepartamentoID uuid.UUID `json:"departamento_id"`
 	NomeGerente    string    `json:"nome_gerente"`
+	Version        int64     `json:"version"`
 	CreatedAt      time.Time `json:"created_at"`
 	UpdatedAt      time.Time `json:"updated_at"`
 }
@@ -39,3 +67,26 @@ type ListColaboradoresResponse struct {
 	PageSize   int                 `json:"page_size"`
 	TotalPages int                 `json:"total_pages"`
 }
+
+// DeleteColaboradorOptions names who takes over the departments managed by
+// the colaborador being deleted. Successors maps a departamento to its own
+// successor; SuccessorGerenteID covers the departamentos not listed there.
+type DeleteColaboradorOptions struct {
+	SuccessorGerenteID *uuid.UUID
+	Successors         map[uuid.UUID]uuid.UUID
+}
+
+// SuccessorFor returns who takes over departamentoID, or nil if nobody was
+// named.
+func (o *DeleteColaboradorOptions) SuccessorFor(departamentoID uuid.UUID) *uuid.UUID {
+	if successorID, ok := o.Successors[departamentoID]; ok {
+		return &successorID
+	}
+	return o.SuccessorGerenteID
+}
+
+// ColaboradorDependents lists the departments that block a colaborador from
+// being deleted.
+type ColaboradorDependents struct {
+	Departamentos []DependentSummary `json:"departamentos"`
+}
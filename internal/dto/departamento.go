@@ -13,18 +13,154 @@ type CreateDepartamentoRequest struct {
 	DepartamentoSuperiorID *uuid.UUID `json:"departamento_superior_id"`
 }
 
+type BootstrapGerenteRequest struct {
+	Nome string  `json:"nome" binding:"required"`
+	CPF  string  `json:"cpf" binding:"required"`
+	RG   *string `json:"rg"`
+}
+
+type BootstrapDepartamentoRequest struct {
+	Nome                   string                  `json:"nome" binding:"required"`
+	DepartamentoSuperiorID *uuid.UUID              `json:"departamento_superior_id"`
+	Gerente                BootstrapGerenteRequest `json:"gerente" binding:"required"`
+}
+
 type UpdateDepartamentoRequest struct {
 	Nome                   string     `json:"nome"`
 	GerenteID              *uuid.UUID `json:"gerente_id"`
 	DepartamentoSuperiorID *uuid.UUID `json:"departamento_superior_id"`
 }
 
+// PatchDepartamentoRequest is a JSON Merge Patch: absent members are left
+// untouched and a null departamento_superior_id turns the department into a
+// root.
+type PatchDepartamentoRequest struct {
+	Nome                   Optional[string]    `json:"nome" swaggertype:"string"`
+	GerenteID              Optional[uuid.UUID] `json:"gerente_id" swaggertype:"string"`
+	DepartamentoSuperiorID Optional[uuid.UUID] `json:"departamento_superior_id" swaggertype:"string"`
+}
+
+// ToPatch expresses PUT semantics as a patch: an empty nome is ignored and
+// uuid.Nil as superior removes it.
+func (r *UpdateDepartamentoRequest) ToPatch() *PatchDepartamentoRequest {
+	patch := &PatchDepartamentoRequest{}
+	if r.Nome != "" {
+		patch.Nome = Some(r.Nome)
+	}
+	if r.GerenteID != nil {
+		patch.GerenteID = Some(*r.GerenteID)
+	}
+	if r.DepartamentoSuperiorID != nil {
+		if *r.DepartamentoSuperiorID == uuid.Nil {
+			patch.DepartamentoSuperiorID = Null[uuid.UUID]()
+		} else {
+			patch.DepartamentoSuperiorID = Some(*r.DepartamentoSuperiorID)
+		}
+	}
+	return patch
+}
+
+// ChangeGerenteRequest hands a departamento over to a new gerente, who is
+// moved into it if needed. The outgoing gerente can be relocated to another
+// departamento. The change always applies immediately: EffectiveDate only
+// backdates GerenteDesde, defaults to now and cannot be in the future.
+type ChangeGerenteRequest struct {
+	GerenteID          uuid.UUID  `json:"gerente_id" binding:"required"`
+	RelocateOutgoingTo *uuid.UUID `json:"relocate_outgoing_to"`
+	EffectiveDate      *time.Time `json:"effective_date"`
+}
+
+// MoveDepartamentoRequest places a departamento, with its whole subtree,
+// under a new superior; a null or absent superior makes it a root.
+type MoveDepartamentoRequest struct {
+	DepartamentoSuperiorID *uuid.UUID `json:"departamento_superior_id"`
+}
+
+// DepartamentoRef is a departamento as it appears in a hierarchy path.
+type DepartamentoRef struct {
+	ID   uuid.UUID `json:"id"`
+	Nome string    `json:"nome"`
+}
+
+// DepartamentoAncestral is one step of the breadcrumb from the root down to a
+// departamento; Depth is 0 for the root.
+type DepartamentoAncestral struct {
+	ID                     uuid.UUID          `json:"id"`
+	Nome                   string             `json:"nome"`
+	DepartamentoSuperiorID *uuid.UUID         `json:"departamento_superior_id"`
+	Gerente                *model.Colaborador `json:"gerente"`
+	Depth                  int                `json:"depth"`
+}
+
+// MoveDepartamentoResponse shows the path from the root to the departamento
+// before and after the move.
+type MoveDepartamentoResponse struct {
+	Departamento    *model.Departamento `json:"departamento"`
+	CaminhoAnterior []DepartamentoRef   `json:"caminho_anterior"`
+	CaminhoAtual    []DepartamentoRef   `json:"caminho_atual"`
+}
+
+const (
+	SurvivingGerenteTarget = "target"
+	SurvivingGerenteSource = "source"
+
+	SourceActionArchive = "archive"
+	SourceActionDelete  = "delete"
+)
+
+// MergeDepartamentoRequest folds the departamento in the path into TargetID.
+// SurvivingGerente picks whose gerente runs the merged departamento
+// (default "target"); SourceAction either archives the source as a soft
+// delete, restorable later, or deletes it for good (default "archive").
+type MergeDepartamentoRequest struct {
+	TargetID         uuid.UUID `json:"target_id" binding:"required"`
+	SurvivingGerente string    `json:"surviving_gerente" binding:"omitempty,oneof=target source"`
+	SourceAction     string    `json:"source_action" binding:"omitempty,oneof=archive delete"`
+}
+
+// MergeDepartamentoResponse summarizes everything the merge moved.
+type MergeDepartamentoResponse struct {
+	Departamento            *model.Departamento `json:"departamento"`
+	Origem                  DepartamentoRef     `json:"origem"`
+	AcaoOrigem              string              `json:"acao_origem"`
+	GerenteID               uuid.UUID           `json:"gerente_id"`
+	ColaboradoresMovidos    []DependentSummary  `json:"colaboradores_movidos"`
+	SubdepartamentosMovidos []DepartamentoRef   `json:"subdepartamentos_movidos"`
+}
+
+const (
+	SplitPositionSibling = "sibling"
+	SplitPositionChild   = "child"
+)
+
+// SplitDepartamentoRequest carves a new departamento out of the one in the
+// path, as its sibling (default) or child, taking the listed colaboradores
+// and direct subdepartamentos. The gerente must be one of the colaboradores.
+type SplitDepartamentoRequest struct {
+	Nome               string      `json:"nome" binding:"required"`
+	GerenteID          uuid.UUID   `json:"gerente_id" binding:"required"`
+	Position           string      `json:"position" binding:"omitempty,oneof=sibling child"`
+	ColaboradorIDs     []uuid.UUID `json:"colaborador_ids" binding:"required,min=1"`
+	SubdepartamentoIDs []uuid.UUID `json:"subdepartamento_ids"`
+}
+
+// SplitDepartamentoResponse summarizes what went to the new departamento.
+type SplitDepartamentoResponse struct {
+	Departamento            *model.Departamento `json:"departamento"`
+	Origem                  DepartamentoRef     `json:"origem"`
+	ColaboradoresMovidos    []DependentSummary  `json:"colaboradores_movidos"`
+	SubdepartamentosMovidos []DepartamentoRef   `json:"subdepartamentos_movidos"`
+}
+
 type DepartamentoResponse struct {
 	ID                     uuid.UUID            `json:"id"`
 	Nome                   string               `json:"nome"`
 	Gerente                *model.Colaborador   `json:"gerente"`
 	DepartamentoSuperiorID *uuid.UUID           `json:"departamento_superior_id,omitempty"`
+	GerenteDesde           *time.Time           `json:"gerente_desde,omitempty"`
+	Depth                  int                  `json:"depth"`
 	Subdepartamentos       []model.Departamento `json:"subdepartamentos"`
+	Version                int64                `json:"version"`
 	CreatedAt              time.Time            `json:"created_at"`
 	UpdatedAt              time.Time            `json:"updated_at"`
 }
@@ -36,3 +172,29 @@ type ListDepartamentosResponse struct {
 	PageSize   int                  `json:"page_size"`
 	TotalPages int                  `json:"total_pages"`
 }
+
+// DeleteDepartamentoOptions says what happens to the departamento's
+// colaboradores and direct subdepartamentos. Whatever is neither reassigned
+// nor reparented is removed along with it when Cascade is set.
+type DeleteDepartamentoOptions struct {
+	ReassignColaboradoresTo *uuid.UUID
+	ReparentChildrenTo      *uuid.UUID
+	Cascade                 bool
+}
+
+// RestoreDepartamentoOptions picks who manages the restored departamento.
+// Without GerenteID it comes back with the gerente it had.
+type RestoreDepartamentoOptions struct {
+	GerenteID *uuid.UUID
+}
+
+type DependentSummary struct {
+	ID   uuid.UUID `json:"id"`
+	Nome string    `json:"nome"`
+}
+
+// DepartamentoDependents lists what blocks a departamento from being deleted.
+type DepartamentoDependents struct {
+	Colaboradores    []DependentSummary `json:"colaboradores"`
+	Subdepartamentos []DependentSummary `json:"subdepartamentos"`
+}
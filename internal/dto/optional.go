@@ -0,0 +1,34 @@
+package dto
+
+import "encoding/json"
+
+// Optional tells apart the three states JSON Merge Patch (RFC 7396) needs: a
+// member absent from the payload (Set false), present as null (Null true),
+// or present with a value.
+type Optional[T any] struct {
+	Set   bool
+	Null  bool
+	Value T
+}
+
+func Some[T any](value T) Optional[T] {
+	return Optional[T]{Set: true, Value: value}
+}
+
+func Null[T any]() Optional[T] {
+	return Optional[T]{Set: true, Null: true}
+}
+
+// UnmarshalJSON is only invoked for members present in the payload, which is
+// what marks the field as Set.
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	if string(data) == "null" {
+		var zero T
+		o.Null = true
+		o.Value = zero
+		return nil
+	}
+	o.Null = false
+	return json.Unmarshal(data, &o.Value)
+}
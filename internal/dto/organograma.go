@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"encoding/base64"
+
+	"github.com/google/uuid"
+
+	"takehome-go/internal/model"
+)
+
+// OrganogramaOptions shapes GET /organograma. MaxDepth counts levels below
+// the starting nodes (nil means the whole tree); Cursor, taken from a node
+// cut off by MaxDepth, starts the tree at that node instead of the roots.
+type OrganogramaOptions struct {
+	MaxDepth             *int
+	IncludeHeadcount     bool
+	IncludeColaboradores bool
+	Cursor               *uuid.UUID
+}
+
+// OrganogramaNode is one departamento of the organisation tree. Cursor is
+// set when the node has subdepartamentos left out by max_depth.
+type OrganogramaNode struct {
+	ID                     uuid.UUID           `json:"id"`
+	Nome                   string              `json:"nome"`
+	Gerente                *model.Colaborador  `json:"gerente"`
+	DepartamentoSuperiorID *uuid.UUID          `json:"departamento_superior_id"`
+	Depth                  int                 `json:"depth"`
+	Headcount              *int64              `json:"headcount,omitempty"`
+	Colaboradores          []model.Colaborador `json:"colaboradores,omitempty"`
+	Subdepartamentos       []OrganogramaNode   `json:"subdepartamentos"`
+	Cursor                 string              `json:"cursor,omitempty"`
+}
+
+type OrganogramaResponse struct {
+	Data []OrganogramaNode `json:"data"`
+}
+
+// EncodeOrganogramaCursor and DecodeOrganogramaCursor keep the cursor opaque
+// to clients; it only identifies the branch to expand.
+func EncodeOrganogramaCursor(id uuid.UUID) string {
+	return base64.RawURLEncoding.EncodeToString(id[:])
+}
+
+func DecodeOrganogramaCursor(cursor string) (uuid.UUID, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return uuid.FromBytes(raw)
+}
+
+const (
+	ExportFormatDOT     = "dot"
+	ExportFormatMermaid = "mermaid"
+	ExportFormatSVG     = "svg"
+)
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"takehome-go/internal/apperror"
+	"takehome-go/internal/dto"
+	"takehome-go/internal/service"
+)
+
+type AdminHandler struct {
+	service          service.AdminService
+	defaultRetention int
+	logger           *zap.Logger
+}
+
+func NewAdminHandler(service service.AdminService, defaultRetention int, logger *zap.Logger) *AdminHandler {
+	return &AdminHandler{
+		service:          service,
+		defaultRetention: defaultRetention,
+		logger:           logger,
+	}
+}
+
+// Purge godoc
+// @Summary Expurgar registros removidos
+// @Description Remove definitivamente colaboradores e departamentos excluídos há mais dias que o período de retenção. O período pode ser estendido, mas nunca reduzido abaixo de PURGE_RETENTION_DAYS
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security AdminToken
+// @Param purge body dto.PurgeRequest false "Período de retenção em dias (padrão e mínimo: PURGE_RETENTION_DAYS)"
+// @Success 200 {object} dto.PurgeResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Router /admin/purge [post]
+func (h *AdminHandler) Purge(c *gin.Context) {
+	var req dto.PurgeRequest
+
+	if c.Request.ContentLength != 0 {
+		if err := bindJSON(c, &req); err != nil {
+			h.logger.Warn("Invalid request body", zap.Error(err))
+			HandleError(c, err)
+			return
+		}
+	}
+
+	retentionDays := h.defaultRetention
+	if req.RetentionDays != nil {
+		if *req.RetentionDays < h.defaultRetention {
+			h.logger.Warn("Retention below minimum", zap.Int("retention_days", *req.RetentionDays), zap.Int("minimum", h.defaultRetention))
+			HandleError(c, apperror.ErrRetentionBelowMinimum)
+			return
+		}
+		retentionDays = *req.RetentionDays
+	}
+
+	response, err := h.service.Purge(c.Request.Context(), time.Duration(retentionDays)*24*time.Hour)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ConsistencyReport godoc
+// @Summary Relatório de consistência
+// @Description Lista os departamentos cujo gerente não existe, foi removido ou pertence a outro departamento
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {object} dto.ConsistencyReport
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/consistency [get]
+func (h *AdminHandler) ConsistencyReport(c *gin.Context) {
+	report, err := h.service.ConsistencyReport(c.Request.Context())
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"takehome-go/internal/dto"
+)
+
+type fakeAdminService struct {
+	purged []time.Duration
+}
+
+func (f *fakeAdminService) Purge(_ context.Context, retention time.Duration) (*dto.PurgeResponse, error) {
+	f.purged = append(f.purged, retention)
+	return &dto.PurgeResponse{}, nil
+}
+
+func (f *fakeAdminService) ConsistencyReport(context.Context) (*dto.ConsistencyReport, error) {
+	return &dto.ConsistencyReport{}, nil
+}
+
+func (f *fakeAdminService) WatchConsistency(context.Context, time.Duration) {}
+
+func TestPurge(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name          string
+		authorization string
+		body          string
+		wantStatus    int
+		wantRetention time.Duration
+	}{
+		{name: "no token", body: "", wantStatus: http.StatusUnauthorized},
+		{name: "wrong token", authorization: "Bearer outro", wantStatus: http.StatusUnauthorized},
+		{name: "default retention", authorization: "Bearer segredo", wantStatus: http.StatusOK, wantRetention: 365 * 24 * time.Hour},
+		{name: "longer retention", authorization: "Bearer segredo", body: `{"retention_days": 400}`, wantStatus: http.StatusOK, wantRetention: 400 * 24 * time.Hour},
+		{name: "shorter retention", authorization: "Bearer segredo", body: `{"retention_days": 30}`, wantStatus: http.StatusUnprocessableEntity},
+		{name: "zero retention", authorization: "Bearer segredo", body: `{"retention_days": 0}`, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &fakeAdminService{}
+			router := gin.New()
+			router.POST("/admin/purge", RequireAdminToken("segredo"), NewAdminHandler(svc, 365, zap.NewNop()).Purge)
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/purge", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (%s)", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantStatus != http.StatusOK {
+				if len(svc.purged) != 0 {
+					t.Fatalf("purge ran on a rejected request")
+				}
+				return
+			}
+			if len(svc.purged) != 1 || svc.purged[0] != tt.wantRetention {
+				t.Fatalf("purged with %v, want %v", svc.purged, tt.wantRetention)
+			}
+		})
+	}
+}
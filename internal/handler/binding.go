@@ -0,0 +1,241 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"takehome-go/internal/apperror"
+)
+
+const mergePatchContentType = "application/merge-patch+json"
+
+var ruleMessages = map[string]string{
+	"required": "Campo obrigatório",
+	"type":     "Tipo inválido",
+	"format":   "Formato inválido",
+	"oneof":    "Valor não permitido",
+	"min":      "Valor abaixo do mínimo",
+}
+
+func init() {
+	// Report binding failures with the JSON field name instead of the Go one.
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(jsonFieldName)
+	}
+}
+
+// bindJSON decodes the request body into obj and, on failure, returns
+// apperror.ErrInvalidBody listing every field that could not be bound.
+func bindJSON(c *gin.Context, obj any) error {
+	err := c.ShouldBindBodyWith(obj, binding.JSON)
+	if err == nil {
+		return nil
+	}
+	return apperror.ErrInvalidBody.WithFields(bindingFieldErrors(c, obj, err)...)
+}
+
+// bindMergePatch accepts RFC 7396 documents (and plain JSON, which has the
+// same shape) into a struct of dto.Optional fields.
+func bindMergePatch(c *gin.Context, obj any) error {
+	switch c.ContentType() {
+	case mergePatchContentType, binding.MIMEJSON:
+		return bindJSON(c, obj)
+	default:
+		return apperror.ErrUnsupportedMediaType
+	}
+}
+
+// queryUUID reads an optional UUID query parameter, appending a field error
+// to fields when it is malformed.
+func queryUUID(c *gin.Context, name string, fields *[]apperror.FieldError) *uuid.UUID {
+	raw, ok := c.GetQuery(name)
+	if !ok || raw == "" {
+		return nil
+	}
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		*fields = append(*fields, newFieldError(name, "format"))
+		return nil
+	}
+	return &id
+}
+
+// queryUUIDMap reads name[<uuid>]=<uuid> query parameters, appending a field
+// error for each malformed key or value.
+func queryUUIDMap(c *gin.Context, name string, fields *[]apperror.FieldError) map[uuid.UUID]uuid.UUID {
+	raw := c.QueryMap(name)
+	if len(raw) == 0 {
+		return nil
+	}
+	values := make(map[uuid.UUID]uuid.UUID, len(raw))
+	for rawKey, rawValue := range raw {
+		key, keyErr := uuid.Parse(rawKey)
+		value, valueErr := uuid.Parse(rawValue)
+		if keyErr != nil || valueErr != nil {
+			*fields = append(*fields, newFieldError(name+"["+rawKey+"]", "format"))
+			continue
+		}
+		values[key] = value
+	}
+	return values
+}
+
+// queryInt reads an optional integer query parameter that must be at least
+// min.
+func queryInt(c *gin.Context, name string, min int, fields *[]apperror.FieldError) *int {
+	raw, ok := c.GetQuery(name)
+	if !ok || raw == "" {
+		return nil
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		*fields = append(*fields, newFieldError(name, "type"))
+		return nil
+	}
+	if value < min {
+		*fields = append(*fields, newFieldError(name, "min"))
+		return nil
+	}
+	return &value
+}
+
+// queryBool reads an optional boolean query parameter; a bare "?name" counts
+// as true.
+func queryBool(c *gin.Context, name string, fields *[]apperror.FieldError) bool {
+	raw, ok := c.GetQuery(name)
+	if !ok {
+		return false
+	}
+	if raw == "" {
+		return true
+	}
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		*fields = append(*fields, newFieldError(name, "type"))
+		return false
+	}
+	return value
+}
+
+func bindingFieldErrors(c *gin.Context, obj any, err error) []apperror.FieldError {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		return validationFieldErrors(validationErrs, nil)
+	}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return nil
+	}
+
+	// Errors raised by field unmarshalers (e.g. an invalid UUID) carry no
+	// field name, so decode each field on its own to find the culprits.
+	body, ok := c.Get(gin.BodyBytesKey)
+	if !ok {
+		return nil
+	}
+	raw, ok := body.([]byte)
+	if !ok {
+		return nil
+	}
+	fields := probeFields(raw, obj)
+
+	// encoding/json keeps decoding after an unmarshaler error, so the
+	// remaining fields can still be validated in the same round-trip.
+	if errors.As(binding.Validator.ValidateStruct(obj), &validationErrs) {
+		fields = append(fields, validationFieldErrors(validationErrs, fields)...)
+	}
+	return fields
+}
+
+func validationFieldErrors(errs validator.ValidationErrors, reported []apperror.FieldError) []apperror.FieldError {
+	seen := make(map[string]bool, len(reported))
+	for _, f := range reported {
+		seen[f.Field] = true
+	}
+
+	fields := make([]apperror.FieldError, 0, len(errs))
+	for _, fe := range errs {
+		name := fieldPath(fe)
+		if seen[name] {
+			continue
+		}
+		fields = append(fields, newFieldError(name, fe.Tag()))
+	}
+	return fields
+}
+
+// fieldPath drops the root struct from the namespace so nested fields are
+// reported as "gerente.cpf".
+func fieldPath(fe validator.FieldError) string {
+	ns := fe.Namespace()
+	if i := strings.Index(ns, "."); i >= 0 {
+		return ns[i+1:]
+	}
+	return fe.Field()
+}
+
+func probeFields(body []byte, obj any) []apperror.FieldError {
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(body, &values); err != nil {
+		return nil
+	}
+
+	t := reflect.TypeOf(obj)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var fields []apperror.FieldError
+	for i := 0; i < t.NumField(); i++ {
+		name := jsonFieldName(t.Field(i))
+		value, ok := values[name]
+		if name == "" || !ok {
+			continue
+		}
+		target := reflect.New(t.Field(i).Type).Interface()
+		if err := json.Unmarshal(value, target); err != nil {
+			rule := "format"
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &typeErr) {
+				rule = "type"
+			}
+			fields = append(fields, newFieldError(name, rule))
+		}
+	}
+	return fields
+}
+
+func newFieldError(field, rule string) apperror.FieldError {
+	message, ok := ruleMessages[rule]
+	if !ok {
+		message = "Valor inválido"
+	}
+	return apperror.FieldError{
+		Field:   field,
+		Rule:    rule,
+		Message: message,
+	}
+}
+
+func jsonFieldName(field reflect.StructField) string {
+	name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 
+	"takehome-go/internal/apperror"
 	"takehome-go/internal/dto"
 	"takehome-go/internal/service"
 )
@@ -32,30 +33,22 @@ func NewColaboradorHandler(service service.ColaboradorService, logger *zap.Logge
 // @Param colaborador body dto.CreateColaboradorRequest true "Dados do colaborador"
 // @Success 201 {object} model.Colaborador
 // @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
 // @Failure 409 {object} ErrorResponse
 // @Failure 422 {object} ErrorResponse
 // @Router /colaboradores [post]
 func (h *ColaboradorHandler) Create(c *gin.Context) {
 	var req dto.CreateColaboradorRequest
 
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		h.logger.Warn("Invalid request body", zap.Error(err))
-		HandleError(c, http.StatusBadRequest, "Dados inválidos")
+		HandleError(c, err)
 		return
 	}
 
 	colaborador, err := h.service.Create(c.Request.Context(), &req)
 	if err != nil {
-		switch err.Error() {
-		case "CPF inválido":
-			HandleError(c, http.StatusUnprocessableEntity, err.Error())
-		case "CPF já cadastrado", "RG já cadastrado":
-			HandleError(c, http.StatusConflict, err.Error())
-		case "Departamento não encontrado":
-			HandleError(c, http.StatusNotFound, err.Error())
-		default:
-			HandleError(c, http.StatusInternalServerError, err.Error())
-		}
+		HandleError(c, err)
 		return
 	}
 
@@ -70,6 +63,7 @@ func (h *ColaboradorHandler) Create(c *gin.Context) {
 // @Produce json
 // @Param id path string true "ID do colaborador"
 // @Success 200 {object} dto.ColaboradorResponse
+// @Header 200 {string} ETag "Versão do registro"
 // @Failure 404 {object} ErrorResponse
 // @Router /colaboradores/{id} [get]
 func (h *ColaboradorHandler) GetByID(c *gin.Context) {
@@ -77,20 +71,17 @@ func (h *ColaboradorHandler) GetByID(c *gin.Context) {
 	id, err := uuid.Parse(idStr)
 	if err != nil {
 		h.logger.Warn("Invalid UUID", zap.String("id", idStr))
-		HandleError(c, http.StatusBadRequest, "ID inválido")
+		HandleError(c, apperror.ErrInvalidID)
 		return
 	}
 
 	colaborador, err := h.service.GetByID(c.Request.Context(), id)
 	if err != nil {
-		if err.Error() == "Colaborador não encontrado" {
-			HandleError(c, http.StatusNotFound, err.Error())
-		} else {
-			HandleError(c, http.StatusInternalServerError, err.Error())
-		}
+		HandleError(c, err)
 		return
 	}
 
+	setETag(c, colaborador.Version)
 	c.JSON(http.StatusOK, colaborador)
 }
 
@@ -101,8 +92,10 @@ func (h *ColaboradorHandler) GetByID(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "ID do colaborador"
+// @Param If-Match header string false "Versão esperada (ETag)"
 // @Param colaborador body dto.UpdateColaboradorRequest true "Dados do colaborador"
 // @Success 200 {object} model.Colaborador
+// @Header 200 {string} ETag "Versão do registro"
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 409 {object} ErrorResponse
@@ -113,73 +106,165 @@ func (h *ColaboradorHandler) Update(c *gin.Context) {
 	id, err := uuid.Parse(idStr)
 	if err != nil {
 		h.logger.Warn("Invalid UUID", zap.String("id", idStr))
-		HandleError(c, http.StatusBadRequest, "ID inválido")
+		HandleError(c, apperror.ErrInvalidID)
 		return
 	}
 
 	var req dto.UpdateColaboradorRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		h.logger.Warn("Invalid request body", zap.Error(err))
-		HandleError(c, http.StatusBadRequest, "Dados inválidos")
+		HandleError(c, err)
+		return
+	}
+
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	colaborador, err := h.service.Update(c.Request.Context(), id, &req, expectedVersion)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	setETag(c, colaborador.Version)
+	c.JSON(http.StatusOK, colaborador)
+}
+
+// Patch godoc
+// @Summary Atualizar colaborador parcialmente
+// @Description Aplica um JSON Merge Patch (RFC 7396): campos ausentes são mantidos e null limpa o campo
+// @Tags colaboradores
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path string true "ID do colaborador"
+// @Param If-Match header string false "Versão esperada (ETag)"
+// @Param colaborador body dto.PatchColaboradorRequest true "Campos a alterar"
+// @Success 200 {object} model.Colaborador
+// @Header 200 {string} ETag "Versão do registro"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
+// @Failure 415 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 428 {object} ErrorResponse
+// @Router /colaboradores/{id} [patch]
+func (h *ColaboradorHandler) Patch(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("Invalid UUID", zap.String("id", idStr))
+		HandleError(c, apperror.ErrInvalidID)
+		return
+	}
+
+	var req dto.PatchColaboradorRequest
+	if err := bindMergePatch(c, &req); err != nil {
+		h.logger.Warn("Invalid patch body", zap.Error(err))
+		HandleError(c, err)
+		return
+	}
+
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		HandleError(c, err)
 		return
 	}
 
-	colaborador, err := h.service.Update(c.Request.Context(), id, &req)
+	colaborador, err := h.service.Patch(c.Request.Context(), id, &req, expectedVersion)
 	if err != nil {
-		switch err.Error() {
-		case "Colaborador não encontrado", "Departamento não encontrado":
-			HandleError(c, http.StatusNotFound, err.Error())
-		case "CPF inválido":
-			HandleError(c, http.StatusUnprocessableEntity, err.Error())
-		case "CPF já cadastrado", "RG já cadastrado":
-			HandleError(c, http.StatusConflict, err.Error())
-		default:
-			HandleError(c, http.StatusInternalServerError, err.Error())
-		}
+		HandleError(c, err)
 		return
 	}
 
+	setETag(c, colaborador.Version)
 	c.JSON(http.StatusOK, colaborador)
 }
 
 // Delete godoc
 // @Summary Deletar colaborador
-// @Description Remove um colaborador
+// @Description Remove um colaborador. Se ele for gerente de algum departamento, informe quem assume cada um: successors[<departamento_id>]=<colaborador_id> por departamento e/ou successor_gerente_id para os demais, sempre um colaborador do próprio departamento. Sem sucessor para algum deles a API responde 409 listando os departamentos pendentes
 // @Tags colaboradores
 // @Accept json
 // @Produce json
 // @Param id path string true "ID do colaborador"
+// @Param successor_gerente_id query string false "Colaborador que assume os departamentos gerenciados sem sucessor próprio"
+// @Param successors query object false "Sucessor por departamento: successors[<departamento_id>]=<colaborador_id>"
 // @Success 204
+// @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse{details=dto.ColaboradorDependents}
+// @Failure 422 {object} ErrorResponse
 // @Router /colaboradores/{id} [delete]
 func (h *ColaboradorHandler) Delete(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
 		h.logger.Warn("Invalid UUID", zap.String("id", idStr))
-		HandleError(c, http.StatusBadRequest, "ID inválido")
+		HandleError(c, apperror.ErrInvalidID)
+		return
+	}
+
+	var fields []apperror.FieldError
+	opts := &dto.DeleteColaboradorOptions{
+		SuccessorGerenteID: queryUUID(c, "successor_gerente_id", &fields),
+		Successors:         queryUUIDMap(c, "successors", &fields),
+	}
+	if len(fields) > 0 {
+		h.logger.Warn("Invalid delete options", zap.String("id", idStr))
+		HandleError(c, apperror.ErrInvalidQuery.WithFields(fields...))
 		return
 	}
 
-	if err := h.service.Delete(c.Request.Context(), id); err != nil {
-		if err.Error() == "Colaborador não encontrado" {
-			HandleError(c, http.StatusNotFound, err.Error())
-		} else {
-			HandleError(c, http.StatusInternalServerError, err.Error())
-		}
+	if err := h.service.Delete(c.Request.Context(), id, opts); err != nil {
+		HandleError(c, err)
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
 
+// Restore godoc
+// @Summary Restaurar colaborador
+// @Description Desfaz a exclusão lógica de um colaborador
+// @Tags colaboradores
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do colaborador"
+// @Success 200 {object} model.Colaborador
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /colaboradores/{id}/restore [post]
+func (h *ColaboradorHandler) Restore(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("Invalid UUID", zap.String("id", idStr))
+		HandleError(c, apperror.ErrInvalidID)
+		return
+	}
+
+	colaborador, err := h.service.Restore(c.Request.Context(), id)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	setETag(c, colaborador.Version)
+	c.JSON(http.StatusOK, colaborador)
+}
+
 // List godoc
 // @Summary Listar colaboradores
 // @Description Lista colaboradores com filtros e paginação
 // @Tags colaboradores
 // @Accept json
 // @Produce json
-// @Param filters body map[string]interface{} false "Filtros (nome, cpf, rg, departamento_id)"
+// @Param filters body map[string]interface{} false "Filtros (nome, cpf, rg, departamento_id, include_deleted)"
 // @Param page query int false "Página" default(1)
 // @Param page_size query int false "Tamanho da página" default(10)
 // @Success 200 {object} dto.ListColaboradoresResponse
@@ -205,7 +290,7 @@ func (h *ColaboradorHandler) List(c *gin.Context) {
 
 	response, err := h.service.List(c.Request.Context(), filters, page, pageSize)
 	if err != nil {
-		HandleError(c, http.StatusInternalServerError, err.Error())
+		HandleError(c, err)
 		return
 	}
 
@@ -7,6 +7,7 @@ import (
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 
+	"takehome-go/internal/apperror"
 	"takehome-go/internal/dto"
 	"takehome-go/internal/service"
 )
@@ -38,22 +39,46 @@ func NewDepartamentoHandler(service service.DepartamentoService, logger *zap.Log
 func (h *DepartamentoHandler) Create(c *gin.Context) {
 	var req dto.CreateDepartamentoRequest
 
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		h.logger.Warn("Invalid request body", zap.Error(err))
-		HandleError(c, http.StatusBadRequest, "Dados inválidos")
+		HandleError(c, err)
 		return
 	}
 
 	departamento, err := h.service.Create(c.Request.Context(), &req)
 	if err != nil {
-		switch err.Error() {
-		case "Gerente não encontrado", "Departamento superior não encontrado":
-			HandleError(c, http.StatusNotFound, err.Error())
-		case "Gerente deve pertencer ao mesmo departamento":
-			HandleError(c, http.StatusUnprocessableEntity, err.Error())
-		default:
-			HandleError(c, http.StatusInternalServerError, err.Error())
-		}
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, departamento)
+}
+
+// Bootstrap godoc
+// @Summary Criar departamento com seu primeiro gerente
+// @Description Cria, de forma atômica, um departamento e um novo colaborador como seu gerente
+// @Tags departamentos
+// @Accept json
+// @Produce json
+// @Param departamento body dto.BootstrapDepartamentoRequest true "Dados do departamento e do gerente"
+// @Success 201 {object} model.Departamento
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Router /departamentos/bootstrap [post]
+func (h *DepartamentoHandler) Bootstrap(c *gin.Context) {
+	var req dto.BootstrapDepartamentoRequest
+
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.Warn("Invalid request body", zap.Error(err))
+		HandleError(c, err)
+		return
+	}
+
+	departamento, err := h.service.Bootstrap(c.Request.Context(), &req)
+	if err != nil {
+		HandleError(c, err)
 		return
 	}
 
@@ -68,6 +93,7 @@ func (h *DepartamentoHandler) Create(c *gin.Context) {
 // @Produce json
 // @Param id path string true "ID do departamento"
 // @Success 200 {object} dto.DepartamentoResponse
+// @Header 200 {string} ETag "Versão do registro"
 // @Failure 404 {object} ErrorResponse
 // @Router /departamentos/{id} [get]
 func (h *DepartamentoHandler) GetByID(c *gin.Context) {
@@ -75,21 +101,129 @@ func (h *DepartamentoHandler) GetByID(c *gin.Context) {
 	id, err := uuid.Parse(idStr)
 	if err != nil {
 		h.logger.Warn("Invalid UUID", zap.String("id", idStr))
-		HandleError(c, http.StatusBadRequest, "ID inválido")
+		HandleError(c, apperror.ErrInvalidID)
 		return
 	}
 
 	departamento, err := h.service.GetByID(c.Request.Context(), id)
 	if err != nil {
-		if err.Error() == "Departamento não encontrado" {
-			HandleError(c, http.StatusNotFound, err.Error())
+		HandleError(c, err)
+		return
+	}
+
+	setETag(c, departamento.Version)
+	c.JSON(http.StatusOK, departamento)
+}
+
+// GetAncestors godoc
+// @Summary Listar ancestrais do departamento
+// @Description Retorna o caminho da raiz até o departamento (breadcrumb), com os gerentes e a profundidade de cada nível
+// @Tags departamentos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do departamento"
+// @Success 200 {array} dto.DepartamentoAncestral
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /departamentos/{id}/ancestrais [get]
+func (h *DepartamentoHandler) GetAncestors(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("Invalid UUID", zap.String("id", idStr))
+		HandleError(c, apperror.ErrInvalidID)
+		return
+	}
+
+	ancestors, err := h.service.GetAncestors(c.Request.Context(), id)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ancestors)
+}
+
+// Organograma godoc
+// @Summary Organograma completo
+// @Description Retorna todos os departamentos raiz com suas árvores. Nós cortados por max_depth trazem um cursor para expandir o ramo depois
+// @Tags organograma
+// @Accept json
+// @Produce json
+// @Param max_depth query int false "Níveis abaixo dos nós iniciais (sem limite se omitido)"
+// @Param include_headcount query bool false "Inclui a quantidade de colaboradores de cada departamento"
+// @Param include_colaboradores query bool false "Inclui os colaboradores de cada departamento"
+// @Param cursor query string false "Cursor de um nó para expandir apenas o seu ramo"
+// @Success 200 {object} dto.OrganogramaResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /organograma [get]
+func (h *DepartamentoHandler) Organograma(c *gin.Context) {
+	var fields []apperror.FieldError
+	opts := &dto.OrganogramaOptions{
+		MaxDepth:             queryInt(c, "max_depth", 0, &fields),
+		IncludeHeadcount:     queryBool(c, "include_headcount", &fields),
+		IncludeColaboradores: queryBool(c, "include_colaboradores", &fields),
+	}
+	if cursor := c.Query("cursor"); cursor != "" {
+		id, err := dto.DecodeOrganogramaCursor(cursor)
+		if err != nil {
+			fields = append(fields, newFieldError("cursor", "format"))
 		} else {
-			HandleError(c, http.StatusInternalServerError, err.Error())
+			opts.Cursor = &id
 		}
+	}
+	if len(fields) > 0 {
+		h.logger.Warn("Invalid organograma options")
+		HandleError(c, apperror.ErrInvalidQuery.WithFields(fields...))
 		return
 	}
 
-	c.JSON(http.StatusOK, departamento)
+	organograma, err := h.service.GetOrganograma(c.Request.Context(), opts)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, organograma)
+}
+
+var exportContentTypes = map[string]string{
+	dto.ExportFormatDOT:     "text/vnd.graphviz; charset=utf-8",
+	dto.ExportFormatMermaid: "text/plain; charset=utf-8",
+	dto.ExportFormatSVG:     "image/svg+xml",
+}
+
+// ExportOrganograma godoc
+// @Summary Exportar organograma
+// @Description Desenha o organograma completo (departamentos com gerente e headcount, ligados ao superior) em Graphviz DOT, Mermaid ou SVG
+// @Tags organograma
+// @Produce plain
+// @Produce image/svg+xml
+// @Param format query string true "Formato de saída" Enums(dot, mermaid, svg)
+// @Success 200 {string} string
+// @Failure 400 {object} ErrorResponse
+// @Router /organograma/export [get]
+func (h *DepartamentoHandler) ExportOrganograma(c *gin.Context) {
+	format, ok := c.GetQuery("format")
+	if !ok || format == "" {
+		HandleError(c, apperror.ErrInvalidQuery.WithFields(newFieldError("format", "required")))
+		return
+	}
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		h.logger.Warn("Invalid export format", zap.String("format", format))
+		HandleError(c, apperror.ErrInvalidQuery.WithFields(newFieldError("format", "oneof")))
+		return
+	}
+
+	body, err := h.service.ExportOrganograma(c.Request.Context(), format)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.Data(http.StatusOK, contentType, body)
 }
 
 // Update godoc
@@ -99,82 +233,376 @@ func (h *DepartamentoHandler) GetByID(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "ID do departamento"
+// @Param If-Match header string false "Versão esperada (ETag)"
 // @Param departamento body dto.UpdateDepartamentoRequest true "Dados do departamento"
 // @Success 200 {object} model.Departamento
+// @Header 200 {string} ETag "Versão do registro"
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
 // @Failure 422 {object} ErrorResponse
+// @Failure 428 {object} ErrorResponse
 // @Router /departamentos/{id} [put]
 func (h *DepartamentoHandler) Update(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
 		h.logger.Warn("Invalid UUID", zap.String("id", idStr))
-		HandleError(c, http.StatusBadRequest, "ID inválido")
+		HandleError(c, apperror.ErrInvalidID)
 		return
 	}
 
 	var req dto.UpdateDepartamentoRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		h.logger.Warn("Invalid request body", zap.Error(err))
-		HandleError(c, http.StatusBadRequest, "Dados inválidos")
+		HandleError(c, err)
 		return
 	}
 
-	departamento, err := h.service.Update(c.Request.Context(), id, &req)
+	expectedVersion, err := ifMatchVersion(c)
 	if err != nil {
-		switch err.Error() {
-		case "Departamento não encontrado", "Gerente não encontrado", "Departamento superior não encontrado":
-			HandleError(c, http.StatusNotFound, err.Error())
-		case "Gerente deve pertencer ao mesmo departamento", "Operação criaria um ciclo na hierarquia de departamentos":
-			HandleError(c, http.StatusUnprocessableEntity, err.Error())
-		default:
-			HandleError(c, http.StatusInternalServerError, err.Error())
-		}
+		HandleError(c, err)
+		return
+	}
+
+	departamento, err := h.service.Update(c.Request.Context(), id, &req, expectedVersion)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	setETag(c, departamento.Version)
+	c.JSON(http.StatusOK, departamento)
+}
+
+// Patch godoc
+// @Summary Atualizar departamento parcialmente
+// @Description Aplica um JSON Merge Patch (RFC 7396): campos ausentes são mantidos e null limpa o campo
+// @Tags departamentos
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path string true "ID do departamento"
+// @Param If-Match header string false "Versão esperada (ETag)"
+// @Param departamento body dto.PatchDepartamentoRequest true "Campos a alterar"
+// @Success 200 {object} model.Departamento
+// @Header 200 {string} ETag "Versão do registro"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
+// @Failure 415 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 428 {object} ErrorResponse
+// @Router /departamentos/{id} [patch]
+func (h *DepartamentoHandler) Patch(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("Invalid UUID", zap.String("id", idStr))
+		HandleError(c, apperror.ErrInvalidID)
+		return
+	}
+
+	var req dto.PatchDepartamentoRequest
+	if err := bindMergePatch(c, &req); err != nil {
+		h.logger.Warn("Invalid patch body", zap.Error(err))
+		HandleError(c, err)
+		return
+	}
+
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	departamento, err := h.service.Patch(c.Request.Context(), id, &req, expectedVersion)
+	if err != nil {
+		HandleError(c, err)
 		return
 	}
 
+	setETag(c, departamento.Version)
 	c.JSON(http.StatusOK, departamento)
 }
 
+// ChangeGerente godoc
+// @Summary Trocar gerente do departamento
+// @Description Nomeia um novo gerente, transferindo-o para o departamento se necessário, registra a data de efetivação e, opcionalmente, realoca o gerente anterior, tudo de forma atômica. A troca vale imediatamente: effective_date apenas retroage gerente_desde e não pode estar no futuro
+// @Tags departamentos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do departamento"
+// @Param If-Match header string false "Versão esperada (ETag)"
+// @Param sucessao body dto.ChangeGerenteRequest true "Novo gerente e opções da sucessão"
+// @Success 200 {object} model.Departamento
+// @Header 200 {string} ETag "Versão do registro"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Router /departamentos/{id}/gerente [post]
+func (h *DepartamentoHandler) ChangeGerente(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("Invalid UUID", zap.String("id", idStr))
+		HandleError(c, apperror.ErrInvalidID)
+		return
+	}
+
+	var req dto.ChangeGerenteRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.Warn("Invalid request body", zap.Error(err))
+		HandleError(c, err)
+		return
+	}
+
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	departamento, err := h.service.ChangeGerente(c.Request.Context(), id, &req, expectedVersion)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	setETag(c, departamento.Version)
+	c.JSON(http.StatusOK, departamento)
+}
+
+// Move godoc
+// @Summary Mover departamento
+// @Description Move o departamento, com toda a sua subárvore, para baixo de um novo superior (null torna-o raiz) e retorna o caminho até a raiz antes e depois
+// @Tags departamentos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do departamento"
+// @Param If-Match header string false "Versão esperada (ETag)"
+// @Param destino body dto.MoveDepartamentoRequest true "Novo departamento superior"
+// @Success 200 {object} dto.MoveDepartamentoResponse
+// @Header 200 {string} ETag "Versão do registro"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Router /departamentos/{id}/mover [post]
+func (h *DepartamentoHandler) Move(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("Invalid UUID", zap.String("id", idStr))
+		HandleError(c, apperror.ErrInvalidID)
+		return
+	}
+
+	var req dto.MoveDepartamentoRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.Warn("Invalid request body", zap.Error(err))
+		HandleError(c, err)
+		return
+	}
+
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	response, err := h.service.Move(c.Request.Context(), id, &req, expectedVersion)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	setETag(c, response.Departamento.Version)
+	c.JSON(http.StatusOK, response)
+}
+
+// Merge godoc
+// @Summary Fundir departamentos
+// @Description Incorpora o departamento ao destino em uma única transação: transfere colaboradores, move subdepartamentos, define o gerente que permanece e arquiva (exclusão lógica) ou remove definitivamente a origem
+// @Tags departamentos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do departamento de origem"
+// @Param If-Match header string false "Versão esperada da origem (ETag)"
+// @Param fusao body dto.MergeDepartamentoRequest true "Destino e opções da fusão"
+// @Success 200 {object} dto.MergeDepartamentoResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Router /departamentos/{id}/merge [post]
+func (h *DepartamentoHandler) Merge(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("Invalid UUID", zap.String("id", idStr))
+		HandleError(c, apperror.ErrInvalidID)
+		return
+	}
+
+	var req dto.MergeDepartamentoRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.Warn("Invalid request body", zap.Error(err))
+		HandleError(c, err)
+		return
+	}
+
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	response, err := h.service.Merge(c.Request.Context(), id, &req, expectedVersion)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Split godoc
+// @Summary Dividir departamento
+// @Description Cria, de forma atômica, um departamento irmão (padrão) ou filho a partir deste, movendo os colaboradores e subdepartamentos diretos informados; o gerente deve estar entre os colaboradores movidos
+// @Tags departamentos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do departamento de origem"
+// @Param divisao body dto.SplitDepartamentoRequest true "Novo departamento e o que ele recebe"
+// @Success 201 {object} dto.SplitDepartamentoResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Router /departamentos/{id}/split [post]
+func (h *DepartamentoHandler) Split(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("Invalid UUID", zap.String("id", idStr))
+		HandleError(c, apperror.ErrInvalidID)
+		return
+	}
+
+	var req dto.SplitDepartamentoRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.Warn("Invalid request body", zap.Error(err))
+		HandleError(c, err)
+		return
+	}
+
+	response, err := h.service.Split(c.Request.Context(), id, &req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
 // Delete godoc
 // @Summary Deletar departamento
-// @Description Remove um departamento
+// @Description Remove um departamento. Se houver colaboradores ou subdepartamentos, informe para onde movê-los ou use cascade para removê-los junto; caso contrário a API responde 409 listando-os
 // @Tags departamentos
 // @Accept json
 // @Produce json
 // @Param id path string true "ID do departamento"
+// @Param reassign_colaboradores_to query string false "Departamento que receberá os colaboradores"
+// @Param reparent_children_to query string false "Novo departamento superior dos subdepartamentos diretos"
+// @Param cascade query bool false "Remove junto o que não for movido (colaboradores e toda a subárvore)"
 // @Success 204
+// @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse{details=dto.DepartamentoDependents}
+// @Failure 422 {object} ErrorResponse
 // @Router /departamentos/{id} [delete]
 func (h *DepartamentoHandler) Delete(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
 		h.logger.Warn("Invalid UUID", zap.String("id", idStr))
-		HandleError(c, http.StatusBadRequest, "ID inválido")
+		HandleError(c, apperror.ErrInvalidID)
 		return
 	}
 
-	if err := h.service.Delete(c.Request.Context(), id); err != nil {
-		if err.Error() == "Departamento não encontrado" {
-			HandleError(c, http.StatusNotFound, err.Error())
-		} else {
-			HandleError(c, http.StatusInternalServerError, err.Error())
-		}
+	var fields []apperror.FieldError
+	opts := &dto.DeleteDepartamentoOptions{
+		ReassignColaboradoresTo: queryUUID(c, "reassign_colaboradores_to", &fields),
+		ReparentChildrenTo:      queryUUID(c, "reparent_children_to", &fields),
+		Cascade:                 queryBool(c, "cascade", &fields),
+	}
+	if len(fields) > 0 {
+		h.logger.Warn("Invalid delete options", zap.String("id", idStr))
+		HandleError(c, apperror.ErrInvalidQuery.WithFields(fields...))
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), id, opts); err != nil {
+		HandleError(c, err)
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
 
+// Restore godoc
+// @Summary Restaurar departamento
+// @Description Desfaz a exclusão lógica de um departamento, restaurando junto os colaboradores removidos na mesma exclusão. Se o gerente tiver sido transferido ou removido por outra operação, informe gerente_id para escolher quem assume; um colaborador de outro departamento é transferido para o restaurado
+// @Tags departamentos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do departamento"
+// @Param gerente_id query string false "Colaborador que assume a gerência do departamento restaurado"
+// @Success 200 {object} model.Departamento
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Router /departamentos/{id}/restore [post]
+func (h *DepartamentoHandler) Restore(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("Invalid UUID", zap.String("id", idStr))
+		HandleError(c, apperror.ErrInvalidID)
+		return
+	}
+
+	var fields []apperror.FieldError
+	opts := &dto.RestoreDepartamentoOptions{
+		GerenteID: queryUUID(c, "gerente_id", &fields),
+	}
+	if len(fields) > 0 {
+		h.logger.Warn("Invalid restore options", zap.String("id", idStr))
+		HandleError(c, apperror.ErrInvalidQuery.WithFields(fields...))
+		return
+	}
+
+	departamento, err := h.service.Restore(c.Request.Context(), id, opts)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	setETag(c, departamento.Version)
+	c.JSON(http.StatusOK, departamento)
+}
+
 // List godoc
 // @Summary Listar departamentos
 // @Description Lista departamentos com filtros e paginação
 // @Tags departamentos
 // @Accept json
 // @Produce json
-// @Param filters body map[string]interface{} false "Filtros (nome, gerente_nome, departamento_superior_id)"
+// @Param filters body map[string]interface{} false "Filtros (nome, gerente_nome, departamento_superior_id, include_deleted)"
 // @Param page query int false "Página" default(1)
 // @Param page_size query int false "Tamanho da página" default(10)
 // @Success 200 {object} dto.ListDepartamentosResponse
@@ -200,7 +628,7 @@ func (h *DepartamentoHandler) List(c *gin.Context) {
 
 	response, err := h.service.List(c.Request.Context(), filters, page, pageSize)
 	if err != nil {
-		HandleError(c, http.StatusInternalServerError, err.Error())
+		HandleError(c, err)
 		return
 	}
 
@@ -222,17 +650,13 @@ func (h *DepartamentoHandler) GetColaboradoresByGerente(c *gin.Context) {
 	id, err := uuid.Parse(idStr)
 	if err != nil {
 		h.logger.Warn("Invalid UUID", zap.String("id", idStr))
-		HandleError(c, http.StatusBadRequest, "ID inválido")
+		HandleError(c, apperror.ErrInvalidID)
 		return
 	}
 
 	colaboradores, err := h.service.GetColaboradoresByGerente(c.Request.Context(), id)
 	if err != nil {
-		if err.Error() == "Gerente não encontrado" {
-			HandleError(c, http.StatusNotFound, err.Error())
-		} else {
-			HandleError(c, http.StatusInternalServerError, err.Error())
-		}
+		HandleError(c, err)
 		return
 	}
 
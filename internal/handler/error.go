@@ -1,20 +1,79 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+
+	"takehome-go/internal/apperror"
+)
+
+const (
+	problemContentType = "application/problem+json"
+	problemTypeBase    = "/problems/"
 )
 
+// ErrorResponse follows RFC 7807 (problem details). Code is the stable
+// machine-readable identifier, Errors lists every invalid field and Details
+// is an extension member with error-specific context.
 type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message"`
+	Type     string                `json:"type"`
+	Title    string                `json:"title"`
+	Status   int                   `json:"status"`
+	Detail   string                `json:"detail"`
+	Instance string                `json:"instance"`
+	Code     string                `json:"code"`
+	Errors   []apperror.FieldError `json:"errors,omitempty"`
+	Details  any                   `json:"details,omitempty"`
+}
+
+var statusByKind = map[apperror.Kind]int{
+	apperror.KindBadRequest:   http.StatusBadRequest,
+	apperror.KindUnauthorized: http.StatusUnauthorized,
+	apperror.KindNotFound:     http.StatusNotFound,
+	apperror.KindConflict:     http.StatusConflict,
+	apperror.KindValidation:   http.StatusUnprocessableEntity,
+	apperror.KindCycle:        http.StatusUnprocessableEntity,
+	apperror.KindInternal:     http.StatusInternalServerError,
+
+	apperror.KindUnsupportedMediaType: http.StatusUnsupportedMediaType,
+	apperror.KindPreconditionFailed:   http.StatusPreconditionFailed,
+	apperror.KindPreconditionRequired: http.StatusPreconditionRequired,
 }
 
-func HandleError(c *gin.Context, statusCode int, message string) {
+// HandleError is the single place where domain errors become HTTP responses.
+// Anything that is not an *apperror.Error is treated as an internal failure.
+func HandleError(c *gin.Context, err error) {
+	var appErr *apperror.Error
+	if !errors.As(err, &appErr) {
+		appErr = apperror.Internal("Erro interno", err)
+	}
+
+	statusCode, ok := statusByKind[appErr.Kind]
+	if !ok {
+		statusCode = http.StatusInternalServerError
+	}
+
+	fields := appErr.Fields
+	if len(fields) == 0 && appErr.Field != "" {
+		fields = []apperror.FieldError{{
+			Field:   appErr.Field,
+			Rule:    appErr.Code,
+			Message: appErr.Message,
+		}}
+	}
+
 	response := ErrorResponse{
-		Error:   http.StatusText(statusCode),
-		Message: message,
+		Type:     problemTypeBase + appErr.Code,
+		Title:    http.StatusText(statusCode),
+		Status:   statusCode,
+		Detail:   appErr.Message,
+		Instance: c.Request.URL.Path,
+		Code:     appErr.Code,
+		Errors:   fields,
+		Details:  appErr.Details,
 	}
+	c.Header("Content-Type", problemContentType)
 	c.JSON(statusCode, response)
 }
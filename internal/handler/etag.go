@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"takehome-go/internal/apperror"
+)
+
+// ETags are the record version, so a client can send back what it read in
+// If-Match and the service rejects the write if someone changed it since.
+func setETag(c *gin.Context, version int64) {
+	c.Header("ETag", strconv.Quote(strconv.FormatInt(version, 10)))
+}
+
+// ifMatchVersion returns the version the client expects, or nil when the
+// header is absent or "*". Weak or malformed tags can never match.
+func ifMatchVersion(c *gin.Context) (*int64, error) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return nil, nil
+	}
+
+	tag, err := strconv.Unquote(header)
+	if err != nil {
+		return nil, apperror.ErrPreconditionFailed
+	}
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil {
+		return nil, apperror.ErrPreconditionFailed
+	}
+	return &version, nil
+}
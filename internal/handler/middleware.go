@@ -1,12 +1,15 @@
 package handler
 
 import (
+	"crypto/subtle"
 	"fmt"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"takehome-go/internal/apperror"
 )
 
 var (
@@ -53,3 +56,30 @@ func PrometheusMiddleware() gin.HandlerFunc {
 		).Observe(duration)
 	}
 }
+
+// RequireIfMatch answers 428 to writes sent without If-Match when required is
+// set, forcing clients through optimistic concurrency control.
+func RequireIfMatch(required bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if required && c.GetHeader("If-Match") == "" {
+			HandleError(c, apperror.ErrPreconditionRequired)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireAdminToken answers 401 unless the request carries
+// "Authorization: Bearer <token>".
+func RequireAdminToken(token string) gin.HandlerFunc {
+	expected := []byte("Bearer " + token)
+	return func(c *gin.Context) {
+		if subtle.ConstantTimeCompare([]byte(c.GetHeader("Authorization")), expected) != 1 {
+			HandleError(c, apperror.ErrUnauthorized)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
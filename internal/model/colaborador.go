@@ -8,13 +8,15 @@ import (
 )
 
 type Colaborador struct {
-	ID             uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
-	Nome           string    `gorm:"not null" json:"nome"`
-	CPF            string    `gorm:"uniqueIndex;not null" json:"cpf"`
-	RG             *string   `gorm:"uniqueIndex" json:"rg,omitempty"`
-	DepartamentoID uuid.UUID `gorm:"type:uuid;not null" json:"departamento_id"`
-	CreatedAt      time.Time `json:"created_at"`
-	UpdatedAt      time.Time `json:"updated_at"`
+	ID             uuid.UUID      `gorm:"type:uuid;primaryKey" json:"id"`
+	Nome           string         `gorm:"not null" json:"nome"`
+	CPF            string         `gorm:"uniqueIndex;not null" json:"cpf"`
+	RG             *string        `gorm:"uniqueIndex" json:"rg,omitempty"`
+	DepartamentoID uuid.UUID      `gorm:"type:uuid;not null" json:"departamento_id"`
+	Version        int64          `gorm:"not null;default:1" json:"version"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty" swaggertype:"string"`
 
 	Departamento *Departamento `gorm:"foreignKey:DepartamentoID" json:"departamento,omitempty"`
 }
@@ -28,4 +30,4 @@ func (c *Colaborador) BeforeCreate(tx *gorm.DB) error {
 		c.ID = uuid.Must(uuid.NewV7())
 	}
 	return nil
-}
\ No newline at end of file
+}
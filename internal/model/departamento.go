@@ -8,12 +8,19 @@ import (
 )
 
 type Departamento struct {
-	ID                     uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
-	Nome                   string     `gorm:"not null" json:"nome"`
-	GerenteID              uuid.UUID  `gorm:"type:uuid;not null" json:"gerente_id"`
-	DepartamentoSuperiorID *uuid.UUID `gorm:"type:uuid" json:"departamento_superior_id,omitempty"`
-	CreatedAt              time.Time  `json:"created_at"`
-	UpdatedAt              time.Time  `json:"updated_at"`
+	ID                     uuid.UUID      `gorm:"type:uuid;primaryKey" json:"id"`
+	Nome                   string         `gorm:"not null" json:"nome"`
+	GerenteID              uuid.UUID      `gorm:"type:uuid;not null" json:"gerente_id"`
+	DepartamentoSuperiorID *uuid.UUID     `gorm:"type:uuid" json:"departamento_superior_id,omitempty"`
+	GerenteDesde           *time.Time     `json:"gerente_desde,omitempty"`
+	Version                int64          `gorm:"not null;default:1" json:"version"`
+	CreatedAt              time.Time      `json:"created_at"`
+	UpdatedAt              time.Time      `json:"updated_at"`
+	DeletedAt              gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty" swaggertype:"string"`
+
+	// Depth is the distance to the root (0 for a root). It is not stored and
+	// is only filled in by hierarchy queries.
+	Depth *int `gorm:"-" json:"depth,omitempty"`
 
 	Gerente              *Colaborador   `gorm:"foreignKey:GerenteID" json:"gerente,omitempty"`
 	DepartamentoSuperior *Departamento  `gorm:"foreignKey:DepartamentoSuperiorID" json:"departamento_superior,omitempty"`
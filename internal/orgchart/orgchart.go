@@ -0,0 +1,43 @@
+package orgchart
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Node is a departamento as drawn in the chart.
+type Node struct {
+	ID        uuid.UUID
+	Nome      string
+	Gerente   string
+	Headcount int64
+	Children  []*Node
+}
+
+// labelLines is the text shown inside every node, whatever the format.
+func labelLines(n *Node) []string {
+	gerente := n.Gerente
+	if gerente == "" {
+		gerente = "-"
+	}
+	return []string{
+		n.Nome,
+		"Gerente: " + gerente,
+		fmt.Sprintf("Colaboradores: %d", n.Headcount),
+	}
+}
+
+// walk visits every node depth-first, parents before their children.
+func walk(roots []*Node, visit func(parent, node *Node)) {
+	var rec func(parent, node *Node)
+	rec = func(parent, node *Node) {
+		visit(parent, node)
+		for _, child := range node.Children {
+			rec(node, child)
+		}
+	}
+	for _, root := range roots {
+		rec(nil, root)
+	}
+}
@@ -0,0 +1,113 @@
+package orgchart
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"unicode/utf8"
+)
+
+const (
+	svgFontSize   = 12
+	svgCharWidth  = 7
+	svgLineHeight = 16
+	svgPadding    = 10
+	svgMinWidth   = 140
+	svgHGap       = 20
+	svgVGap       = 50
+	svgMargin     = 20
+)
+
+type svgBox struct {
+	node  *Node
+	x, y  int
+	lines []string
+}
+
+// SVG renders the chart as a standalone SVG document. Every box has the
+// same size; leaves are laid out left to right and each parent is centered
+// over its children, so branches never overlap.
+func SVG(roots []*Node) []byte {
+	width := svgMinWidth
+	walk(roots, func(_, n *Node) {
+		for _, line := range labelLines(n) {
+			if w := utf8.RuneCountInString(line)*svgCharWidth + 2*svgPadding; w > width {
+				width = w
+			}
+		}
+	})
+	height := 3*svgLineHeight + 2*svgPadding
+
+	boxes := make(map[*Node]*svgBox)
+	nextX := svgMargin
+	maxDepth := 0
+	var place func(n *Node, depth int) int
+	place = func(n *Node, depth int) int {
+		if depth > maxDepth {
+			maxDepth = depth
+		}
+		box := &svgBox{node: n, y: svgMargin + depth*(height+svgVGap), lines: labelLines(n)}
+		boxes[n] = box
+		if len(n.Children) == 0 {
+			box.x = nextX
+			nextX += width + svgHGap
+			return box.x
+		}
+		first := place(n.Children[0], depth+1)
+		last := first
+		for _, child := range n.Children[1:] {
+			last = place(child, depth+1)
+		}
+		box.x = (first + last) / 2
+		return box.x
+	}
+	for _, root := range roots {
+		place(root, 0)
+	}
+
+	totalWidth := max(nextX-svgHGap+svgMargin, width+2*svgMargin)
+	totalHeight := 2*svgMargin + (maxDepth+1)*height + maxDepth*svgVGap
+	if len(roots) == 0 {
+		totalHeight = 2 * svgMargin
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="Helvetica, Arial, sans-serif" font-size="%d">`+"\n",
+		totalWidth, totalHeight, totalWidth, totalHeight, svgFontSize)
+
+	walk(roots, func(parent, n *Node) {
+		if parent == nil {
+			return
+		}
+		from, to := boxes[parent], boxes[n]
+		fromX, fromY := from.x+width/2, from.y+height
+		toX, toY := to.x+width/2, to.y
+		midY := fromY + svgVGap/2
+		fmt.Fprintf(&buf, `  <path d="M %d %d V %d H %d V %d" fill="none" stroke="#555"/>`+"\n", fromX, fromY, midY, toX, toY)
+	})
+
+	walk(roots, func(_, n *Node) {
+		box := boxes[n]
+		fmt.Fprintf(&buf, `  <g id="%s">`+"\n", n.ID.String())
+		fmt.Fprintf(&buf, `    <rect x="%d" y="%d" width="%d" height="%d" rx="6" fill="#f5f7fa" stroke="#333"/>`+"\n", box.x, box.y, width, height)
+		for i, line := range box.lines {
+			weight := ""
+			if i == 0 {
+				weight = ` font-weight="bold"`
+			}
+			fmt.Fprintf(&buf, `    <text x="%d" y="%d" text-anchor="middle"%s>%s</text>`+"\n",
+				box.x+width/2, box.y+svgPadding+(i+1)*svgLineHeight-4, weight, xmlEscape(line))
+		}
+		buf.WriteString("  </g>\n")
+	})
+
+	buf.WriteString("</svg>\n")
+	return buf.Bytes()
+}
+
+func xmlEscape(s string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
@@ -0,0 +1,65 @@
+package orgchart
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// DOT renders the chart as a Graphviz digraph, one box per departamento.
+func DOT(roots []*Node) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("digraph organograma {\n")
+	buf.WriteString("\trankdir=TB;\n")
+	buf.WriteString("\tnode [shape=box, style=rounded, fontname=\"Helvetica\"];\n")
+
+	walk(roots, func(_, n *Node) {
+		lines := labelLines(n)
+		for i, line := range lines {
+			lines[i] = dotEscape(line)
+		}
+		fmt.Fprintf(&buf, "\t%q [label=\"%s\"];\n", n.ID.String(), strings.Join(lines, "\\n"))
+	})
+	walk(roots, func(parent, n *Node) {
+		if parent != nil {
+			fmt.Fprintf(&buf, "\t%q -> %q;\n", parent.ID.String(), n.ID.String())
+		}
+	})
+
+	buf.WriteString("}\n")
+	return buf.Bytes()
+}
+
+func dotEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", " ").Replace(s)
+}
+
+// Mermaid renders the chart as a top-down Mermaid flowchart.
+func Mermaid(roots []*Node) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("flowchart TD\n")
+
+	walk(roots, func(_, n *Node) {
+		lines := labelLines(n)
+		for i, line := range lines {
+			lines[i] = mermaidEscape(line)
+		}
+		fmt.Fprintf(&buf, "    %s[\"%s\"]\n", mermaidID(n), strings.Join(lines, "<br/>"))
+	})
+	walk(roots, func(parent, n *Node) {
+		if parent != nil {
+			fmt.Fprintf(&buf, "    %s --> %s\n", mermaidID(parent), mermaidID(n))
+		}
+	})
+
+	return buf.Bytes()
+}
+
+// mermaidID drops the dashes, which Mermaid does not accept in node ids.
+func mermaidID(n *Node) string {
+	return "d" + strings.ReplaceAll(n.ID.String(), "-", "")
+}
+
+func mermaidEscape(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;", "\n", " ").Replace(s)
+}
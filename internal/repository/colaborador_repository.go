@@ -2,9 +2,11 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"takehome-go/internal/model"
 )
@@ -12,12 +14,20 @@ import (
 type ColaboradorRepository interface {
 	Create(ctx context.Context, colaborador *model.Colaborador) error
 	GetByID(ctx context.Context, id uuid.UUID) (*model.Colaborador, error)
+	GetByIDUnscoped(ctx context.Context, id uuid.UUID) (*model.Colaborador, error)
 	Update(ctx context.Context, colaborador *model.Colaborador) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	Restore(ctx context.Context, id uuid.UUID) error
+	Purge(ctx context.Context, deletedBefore time.Time) (int64, error)
 	List(ctx context.Context, filters map[string]any, page, pageSize int) ([]model.Colaborador, int64, error)
 	ExistsByCPF(ctx context.Context, cpf string, excludeID *uuid.UUID) (bool, error)
 	ExistsByRG(ctx context.Context, rg string, excludeID *uuid.UUID) (bool, error)
 	GetByDepartamentoIDs(ctx context.Context, ids []uuid.UUID) ([]model.Colaborador, error)
+	CountByDepartamentoIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]int64, error)
+	Reassign(ctx context.Context, fromDepartamentoID, toDepartamentoID uuid.UUID) error
+	MoveToDepartamento(ctx context.Context, ids []uuid.UUID, departamentoID uuid.UUID) error
+	DeleteByDepartamentoIDs(ctx context.Context, ids []uuid.UUID, deletedAt time.Time) error
+	RestoreDeletedWith(ctx context.Context, departamentoID uuid.UUID, deletedAt time.Time) error
 }
 
 type colaboradorRepository struct {
@@ -43,20 +53,76 @@ func (r *colaboradorRepository) GetByID(ctx context.Context, id uuid.UUID) (*mod
 	return &colaborador, nil
 }
 
+// GetByIDUnscoped also finds soft-deleted colaboradores.
+func (r *colaboradorRepository) GetByIDUnscoped(ctx context.Context, id uuid.UUID) (*model.Colaborador, error) {
+	var colaborador model.Colaborador
+	err := r.db.WithContext(ctx).
+		Unscoped().
+		First(&colaborador, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &colaborador, nil
+}
+
+// Update writes every column only if the stored version still matches the
+// one that was read, and bumps it. A concurrent change yields ErrStaleVersion.
 func (r *colaboradorRepository) Update(ctx context.Context, colaborador *model.Colaborador) error {
-	return r.db.WithContext(ctx).Save(colaborador).Error
+	expected := colaborador.Version
+	colaborador.Version++
+
+	result := r.db.WithContext(ctx).
+		Model(colaborador).
+		Select("*").
+		Omit(clause.Associations).
+		Where("version = ?", expected).
+		Updates(colaborador)
+	if result.Error != nil {
+		colaborador.Version = expected
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		colaborador.Version = expected
+		return ErrStaleVersion
+	}
+	return nil
 }
 
 func (r *colaboradorRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return r.db.WithContext(ctx).Delete(&model.Colaborador{}, "id = ?", id).Error
 }
 
+func (r *colaboradorRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Unscoped().
+		Model(&model.Colaborador{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Updates(map[string]interface{}{
+			"deleted_at": nil,
+			"version":    gorm.Expr("version + 1"),
+		}).Error
+}
+
+// Purge hard-deletes colaboradores soft-deleted before the cutoff, skipping
+// any still referenced as gerente so the FK never blocks the batch.
+func (r *colaboradorRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Unscoped().
+		Where("deleted_at < ?", deletedBefore).
+		Where("NOT EXISTS (SELECT 1 FROM departamentos d WHERE d.gerente_id = colaboradores.id)").
+		Delete(&model.Colaborador{})
+	return result.RowsAffected, result.Error
+}
+
 func (r *colaboradorRepository) List(ctx context.Context, filters map[string]interface{}, page, pageSize int) ([]model.Colaborador, int64, error) {
 	var colaboradores []model.Colaborador
 	var total int64
 
 	query := r.db.WithContext(ctx).Model(&model.Colaborador{})
 
+	if includeDeleted, ok := filters["include_deleted"].(bool); ok && includeDeleted {
+		query = query.Unscoped()
+	}
 	if nome, ok := filters["nome"].(string); ok && nome != "" {
 		query = query.Where("nome ILIKE ?", "%"+nome+"%")
 	}
@@ -82,9 +148,11 @@ func (r *colaboradorRepository) List(ctx context.Context, filters map[string]int
 	return colaboradores, total, err
 }
 
+// ExistsByCPF and ExistsByRG include soft-deleted rows: the unique
+// constraints still hold them, and a restore must not collide.
 func (r *colaboradorRepository) ExistsByCPF(ctx context.Context, cpf string, excludeID *uuid.UUID) (bool, error) {
 	var count int64
-	query := r.db.WithContext(ctx).Model(&model.Colaborador{}).Where("cpf = ?", cpf)
+	query := r.db.WithContext(ctx).Unscoped().Model(&model.Colaborador{}).Where("cpf = ?", cpf)
 	if excludeID != nil {
 		query = query.Where("id != ?", *excludeID)
 	}
@@ -94,7 +162,7 @@ func (r *colaboradorRepository) ExistsByCPF(ctx context.Context, cpf string, exc
 
 func (r *colaboradorRepository) ExistsByRG(ctx context.Context, rg string, excludeID *uuid.UUID) (bool, error) {
 	var count int64
-	query := r.db.WithContext(ctx).Model(&model.Colaborador{}).Where("rg = ?", rg)
+	query := r.db.WithContext(ctx).Unscoped().Model(&model.Colaborador{}).Where("rg = ?", rg)
 	if excludeID != nil {
 		query = query.Where("id != ?", *excludeID)
 	}
@@ -110,3 +178,82 @@ func (r *colaboradorRepository) GetByDepartamentoIDs(ctx context.Context, ids []
 		Find(&colaboradores).Error
 	return colaboradores, err
 }
+
+// CountByDepartamentoIDs returns how many active colaboradores each
+// departamento has; departamentos without any are left out of the map.
+func (r *colaboradorRepository) CountByDepartamentoIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]int64, error) {
+	type countResult struct {
+		DepartamentoID uuid.UUID
+		Total          int64
+	}
+
+	var results []countResult
+	err := r.db.WithContext(ctx).
+		Model(&model.Colaborador{}).
+		Select("departamento_id, COUNT(*) AS total").
+		Where("departamento_id IN ?", ids).
+		Group("departamento_id").
+		Scan(&results).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uuid.UUID]int64, len(results))
+	for _, res := range results {
+		counts[res.DepartamentoID] = res.Total
+	}
+	return counts, nil
+}
+
+// Reassign moves every colaborador of one departamento to another.
+// Soft-deleted ones follow too, so a restore lands them in an active
+// departamento.
+func (r *colaboradorRepository) Reassign(ctx context.Context, fromDepartamentoID, toDepartamentoID uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Unscoped().
+		Model(&model.Colaborador{}).
+		Where("departamento_id = ?", fromDepartamentoID).
+		Updates(map[string]interface{}{
+			"departamento_id": toDepartamentoID,
+			"version":         gorm.Expr("version + 1"),
+		}).Error
+}
+
+func (r *colaboradorRepository) MoveToDepartamento(ctx context.Context, ids []uuid.UUID, departamentoID uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Model(&model.Colaborador{}).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{
+			"departamento_id": departamentoID,
+			"version":         gorm.Expr("version + 1"),
+		}).Error
+}
+
+// DeleteByDepartamentoIDs soft-deletes the active colaboradores of the
+// departamentos stamping them with deletedAt, the same instant the
+// departamentos get, so RestoreDeletedWith can tell them apart from
+// colaboradores removed earlier on their own.
+func (r *colaboradorRepository) DeleteByDepartamentoIDs(ctx context.Context, ids []uuid.UUID, deletedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Model(&model.Colaborador{}).
+		Where("departamento_id IN ?", ids).
+		Update("deleted_at", deletedAt).Error
+}
+
+// RestoreDeletedWith undoes DeleteByDepartamentoIDs for one departamento.
+func (r *colaboradorRepository) RestoreDeletedWith(ctx context.Context, departamentoID uuid.UUID, deletedAt time.Time) error {
+	return r.db.WithContext(ctx).
+		Unscoped().
+		Model(&model.Colaborador{}).
+		Where("departamento_id = ? AND deleted_at = ?", departamentoID, deletedAt).
+		Updates(map[string]interface{}{
+			"deleted_at": nil,
+			"version":    gorm.Expr("version + 1"),
+		}).Error
+}
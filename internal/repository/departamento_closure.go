@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// departamento_closure holds one row per (ancestor, descendant) pair of the
+// hierarchy, self pairs included at depth 0, so subtree and ancestor lookups
+// are a single indexed query instead of a recursive CTE. Soft-deleted
+// departamentos keep their rows; readers filter on deleted_at. The write
+// paths below keep it in step with departamento_superior_id and hard
+// deletes are cleaned up by ON DELETE CASCADE.
+
+// linkClosure adds a new departamento below superiorID (nil for a root).
+func linkClosure(db *gorm.DB, id uuid.UUID, superiorID *uuid.UUID) error {
+	return db.Exec(`
+		INSERT INTO departamento_closure (ancestor_id, descendant_id, depth)
+		SELECT $1::uuid, $1::uuid, 0
+		UNION ALL
+		SELECT ancestor_id, $1::uuid, depth + 1
+		FROM departamento_closure
+		WHERE descendant_id = $2::uuid
+	`, id, superiorID).Error
+}
+
+// relinkClosure moves the subtree rooted at id below superiorID (nil for a
+// root): pairs linking the subtree to its old ancestors are dropped and
+// every new ancestor is paired with every node of the subtree.
+func relinkClosure(db *gorm.DB, id uuid.UUID, superiorID *uuid.UUID) error {
+	err := db.Exec(`
+		DELETE FROM departamento_closure c
+		USING departamento_closure sub
+		WHERE sub.ancestor_id = $1
+			AND c.descendant_id = sub.descendant_id
+			AND c.ancestor_id NOT IN (
+				SELECT descendant_id FROM departamento_closure WHERE ancestor_id = $1
+			)
+	`, id).Error
+	if err != nil {
+		return err
+	}
+
+	return db.Exec(`
+		INSERT INTO departamento_closure (ancestor_id, descendant_id, depth)
+		SELECT sup.ancestor_id, sub.descendant_id, sup.depth + sub.depth + 1
+		FROM departamento_closure sup
+		CROSS JOIN departamento_closure sub
+		WHERE sup.descendant_id = $2::uuid AND sub.ancestor_id = $1::uuid
+	`, id, superiorID).Error
+}
+
+// closureParent returns the superior the closure table currently records
+// for id, nil for a root.
+func closureParent(db *gorm.DB, id uuid.UUID) (*uuid.UUID, error) {
+	var parents []uuid.UUID
+	err := db.Raw(`
+		SELECT ancestor_id FROM departamento_closure WHERE descendant_id = $1 AND depth = 1
+	`, id).Scan(&parents).Error
+	if err != nil || len(parents) == 0 {
+		return nil, err
+	}
+	return &parents[0], nil
+}
+
+func sameSuperior(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// RebuildClosure recomputes the whole closure table from
+// departamento_superior_id and returns how many pairs it holds. Paths stop
+// before revisiting a departamento, so a corrupted hierarchy with a loop
+// cannot make the rebuild run forever.
+func (r *departamentoRepository) RebuildClosure(ctx context.Context) (int64, error) {
+	var rows int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM departamento_closure").Error; err != nil {
+			return err
+		}
+
+		result := tx.Exec(`
+			WITH RECURSIVE closure AS (
+				SELECT id AS ancestor_id, id AS descendant_id, 0 AS depth, ARRAY[id] AS caminho
+				FROM departamentos
+
+				UNION ALL
+
+				SELECT c.ancestor_id, d.id, c.depth + 1, c.caminho || d.id
+				FROM closure c
+				INNER JOIN departamentos d ON d.departamento_superior_id = c.descendant_id
+				WHERE NOT d.id = ANY(c.caminho)
+			)
+			INSERT INTO departamento_closure (ancestor_id, descendant_id, depth)
+			SELECT ancestor_id, descendant_id, depth FROM closure
+			ON CONFLICT DO NOTHING
+		`)
+		rows = result.RowsAffected
+		return result.Error
+	})
+	return rows, err
+}
@@ -2,22 +2,41 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"takehome-go/internal/model"
 )
 
 type DepartamentoRepository interface {
 	Create(ctx context.Context, departamento *model.Departamento) error
+	CreateWithoutGerente(ctx context.Context, departamento *model.Departamento) error
 	GetByID(ctx context.Context, id uuid.UUID) (*model.Departamento, error)
+	GetByIDUnscoped(ctx context.Context, id uuid.UUID) (*model.Departamento, error)
 	GetByIDWithHierarchy(ctx context.Context, id uuid.UUID) (*model.Departamento, error)
+	GetTree(ctx context.Context, rootID *uuid.UUID, maxDepth *int) ([]TreeNode, error)
 	Update(ctx context.Context, departamento *model.Departamento) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	DeleteByIDs(ctx context.Context, ids []uuid.UUID, deletedAt time.Time) error
+	HardDelete(ctx context.Context, id uuid.UUID) error
+	DeleteAtVersion(ctx context.Context, id uuid.UUID, version int64) error
+	HardDeleteAtVersion(ctx context.Context, id uuid.UUID, version int64) error
+	Restore(ctx context.Context, id uuid.UUID) error
+	Purge(ctx context.Context, deletedBefore time.Time) (PurgeResult, error)
+	GetChildren(ctx context.Context, id uuid.UUID) ([]model.Departamento, error)
+	GetByGerenteID(ctx context.Context, gerenteID uuid.UUID) ([]model.Departamento, error)
+	FindGerenteViolations(ctx context.Context, departamentoIDs, gerenteIDs []uuid.UUID) ([]GerenteViolation, error)
+	Reparent(ctx context.Context, fromID, toID uuid.UUID) error
+	SetSuperior(ctx context.Context, ids []uuid.UUID, superiorID uuid.UUID) error
 	List(ctx context.Context, filters map[string]interface{}, page, pageSize int) ([]model.Departamento, int64, error)
 	HasCycle(ctx context.Context, id, superiorID uuid.UUID) (bool, error)
+	GetAncestors(ctx context.Context, id uuid.UUID) ([]model.Departamento, error)
+	GetDepth(ctx context.Context, id uuid.UUID) (int, error)
 	GetSubdepartamentosRecursive(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error)
+	RebuildClosure(ctx context.Context) (int64, error)
 }
 
 type departamentoRepository struct {
@@ -29,7 +48,25 @@ func NewDepartamentoRepository(db *gorm.DB) DepartamentoRepository {
 }
 
 func (r *departamentoRepository) Create(ctx context.Context, departamento *model.Departamento) error {
-	return r.db.WithContext(ctx).Create(departamento).Error
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(departamento).Error; err != nil {
+			return err
+		}
+		return linkClosure(tx, departamento.ID, departamento.DepartamentoSuperiorID)
+	})
+	return translateError(err)
+}
+
+// CreateWithoutGerente inserts the departamento leaving gerente_id NULL, so its
+// first gerente can be created afterwards inside the same transaction.
+func (r *departamentoRepository) CreateWithoutGerente(ctx context.Context, departamento *model.Departamento) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("GerenteID").Create(departamento).Error; err != nil {
+			return err
+		}
+		return linkClosure(tx, departamento.ID, departamento.DepartamentoSuperiorID)
+	})
+	return translateError(err)
 }
 
 func (r *departamentoRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.Departamento, error) {
@@ -43,56 +80,149 @@ func (r *departamentoRepository) GetByID(ctx context.Context, id uuid.UUID) (*mo
 	return &departamento, nil
 }
 
+// GetByIDUnscoped also finds soft-deleted departamentos.
+func (r *departamentoRepository) GetByIDUnscoped(ctx context.Context, id uuid.UUID) (*model.Departamento, error) {
+	var departamento model.Departamento
+	err := r.db.WithContext(ctx).
+		Unscoped().
+		First(&departamento, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &departamento, nil
+}
+
 func (r *departamentoRepository) GetByIDWithHierarchy(ctx context.Context, id uuid.UUID) (*model.Departamento, error) {
+	nodes, err := r.GetTree(ctx, &id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(nodes) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	children := make(map[uuid.UUID][]*model.Departamento)
+	for i := range nodes[1:] {
+		dept := &nodes[i+1].Departamento
+		children[*dept.DepartamentoSuperiorID] = append(children[*dept.DepartamentoSuperiorID], dept)
+	}
+
+	root := nestSubdepartamentos(&nodes[0].Departamento, children)
+	return &root, nil
+}
+
+// nestSubdepartamentos attaches the children of dept depth-first. Children
+// are copied into Subdepartamentos by value, so each one has to be complete
+// before it is appended or its own subtree would be lost.
+func nestSubdepartamentos(dept *model.Departamento, children map[uuid.UUID][]*model.Departamento) model.Departamento {
+	dept.Subdepartamentos = []model.Departamento{}
+	for _, child := range children[dept.ID] {
+		dept.Subdepartamentos = append(dept.Subdepartamentos, nestSubdepartamentos(child, children))
+	}
+	return *dept
+}
+
+// TreeNode is a departamento as returned by GetTree. HasChildren reports
+// active subdepartamentos even when maxDepth left them out.
+type TreeNode struct {
+	Departamento model.Departamento
+	HasChildren  bool
+}
+
+// GetTree reads the subtree of rootID, or of every root departamento when
+// it is nil, from the closure table, stopping maxDepth levels below the
+// start (no limit when nil). Nodes come flat, level by level and siblings
+// by nome, with gerentes and absolute depths filled in.
+func (r *departamentoRepository) GetTree(ctx context.Context, rootID *uuid.UUID, maxDepth *int) ([]TreeNode, error) {
 	type DeptResult struct {
 		ID                     uuid.UUID
 		Nome                   string
 		GerenteID              uuid.UUID
 		DepartamentoSuperiorID *uuid.UUID
+		GerenteDesde           *time.Time
+		Version                int64
+		CreatedAt              time.Time
+		UpdatedAt              time.Time
+		Nivel                  int
+		HasChildren            bool
 	}
 
 	query := `
-		WITH RECURSIVE dept_tree AS (
-			SELECT id, nome, gerente_id, departamento_superior_id
-			FROM departamentos
-			WHERE id = $1
-			
-			UNION ALL
-			
-			SELECT d.id, d.nome, d.gerente_id, d.departamento_superior_id
-			FROM departamentos d
-			INNER JOIN dept_tree dt ON d.departamento_superior_id = dt.id
-		)
-		SELECT * FROM dept_tree
+		SELECT d.id, d.nome, d.gerente_id, d.departamento_superior_id, d.gerente_desde, d.version, d.created_at, d.updated_at,
+			c.depth AS nivel,
+			EXISTS (
+				SELECT 1 FROM departamentos sub
+				WHERE sub.departamento_superior_id = d.id AND sub.deleted_at IS NULL
+			) AS has_children
+		FROM departamentos r
+		INNER JOIN departamento_closure c ON c.ancestor_id = r.id
+		INNER JOIN departamentos d ON d.id = c.descendant_id
+		WHERE r.deleted_at IS NULL
+			AND d.deleted_at IS NULL
+			AND (($1::uuid IS NULL AND r.departamento_superior_id IS NULL) OR r.id = $1::uuid)
+			AND ($2::int IS NULL OR c.depth <= $2::int)
+		ORDER BY c.depth, d.nome
 	`
 
 	var results []DeptResult
-	if err := r.db.WithContext(ctx).Raw(query, id).Scan(&results).Error; err != nil {
+	if err := r.db.WithContext(ctx).Raw(query, rootID, maxDepth).Scan(&results).Error; err != nil {
 		return nil, err
 	}
 
 	if len(results) == 0 {
-		return nil, gorm.ErrRecordNotFound
+		return nil, nil
 	}
 
-	deptMap := make(map[uuid.UUID]*model.Departamento)
-	var gerenteIDs []uuid.UUID
+	rootDepth := 0
+	if rootID != nil {
+		depth, err := r.GetDepth(ctx, *rootID)
+		if err != nil {
+			return nil, err
+		}
+		rootDepth = depth
+	}
 
+	nodes := make([]TreeNode, 0, len(results))
 	for _, res := range results {
-		dept := &model.Departamento{
-			ID:                     res.ID,
-			Nome:                   res.Nome,
-			GerenteID:              res.GerenteID,
-			DepartamentoSuperiorID: res.DepartamentoSuperiorID,
-			Subdepartamentos:       []model.Departamento{},
-		}
-		deptMap[res.ID] = dept
-		gerenteIDs = append(gerenteIDs, res.GerenteID)
+		depth := rootDepth + res.Nivel
+		nodes = append(nodes, TreeNode{
+			Departamento: model.Departamento{
+				ID:                     res.ID,
+				Nome:                   res.Nome,
+				GerenteID:              res.GerenteID,
+				DepartamentoSuperiorID: res.DepartamentoSuperiorID,
+				GerenteDesde:           res.GerenteDesde,
+				Version:                res.Version,
+				CreatedAt:              res.CreatedAt,
+				UpdatedAt:              res.UpdatedAt,
+				Depth:                  &depth,
+			},
+			HasChildren: res.HasChildren,
+		})
+	}
+
+	depts := make([]*model.Departamento, 0, len(nodes))
+	for i := range nodes {
+		depts = append(depts, &nodes[i].Departamento)
+	}
+	if err := r.loadGerentes(ctx, depts); err != nil {
+		return nil, err
+	}
+
+	return nodes, nil
+}
+
+// loadGerentes fills in the Gerente of each departamento with one query.
+func (r *departamentoRepository) loadGerentes(ctx context.Context, depts []*model.Departamento) error {
+	gerenteIDs := make([]uuid.UUID, 0, len(depts))
+	for _, dept := range depts {
+		gerenteIDs = append(gerenteIDs, dept.GerenteID)
 	}
 
 	var gerentes []model.Colaborador
 	if err := r.db.WithContext(ctx).Where("id IN ?", gerenteIDs).Find(&gerentes).Error; err != nil {
-		return nil, err
+		return err
 	}
 
 	gerenteMap := make(map[uuid.UUID]*model.Colaborador)
@@ -100,29 +230,266 @@ func (r *departamentoRepository) GetByIDWithHierarchy(ctx context.Context, id uu
 		gerenteMap[gerentes[i].ID] = &gerentes[i]
 	}
 
-	for _, dept := range deptMap {
+	for _, dept := range depts {
 		if gerente, ok := gerenteMap[dept.GerenteID]; ok {
 			dept.Gerente = gerente
 		}
 	}
+	return nil
+}
+
+// Update writes every column only if the stored version still matches the
+// one that was read, and bumps it. A concurrent change yields ErrStaleVersion.
+// A new superior moves the whole subtree in the closure table.
+func (r *departamentoRepository) Update(ctx context.Context, departamento *model.Departamento) error {
+	expected := departamento.Version
+	departamento.Version++
 
-	for _, dept := range deptMap {
-		if dept.DepartamentoSuperiorID != nil {
-			if parent, ok := deptMap[*dept.DepartamentoSuperiorID]; ok {
-				parent.Subdepartamentos = append(parent.Subdepartamentos, *dept)
-			}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.
+			Model(departamento).
+			Select("*").
+			Omit(clause.Associations).
+			Where("version = ?", expected).
+			Updates(departamento)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrStaleVersion
+		}
+
+		parentID, err := closureParent(tx, departamento.ID)
+		if err != nil {
+			return err
+		}
+		if sameSuperior(parentID, departamento.DepartamentoSuperiorID) {
+			return nil
 		}
+		return relinkClosure(tx, departamento.ID, departamento.DepartamentoSuperiorID)
+	})
+	if err != nil {
+		departamento.Version = expected
 	}
+	return translateError(err)
+}
 
-	return deptMap[id], nil
+func (r *departamentoRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&model.Departamento{}, "id = ?", id).Error
 }
 
-func (r *departamentoRepository) Update(ctx context.Context, departamento *model.Departamento) error {
-	return r.db.WithContext(ctx).Save(departamento).Error
+// DeleteByIDs soft-deletes the departamentos at deletedAt; see
+// ColaboradorRepository.DeleteByDepartamentoIDs.
+func (r *departamentoRepository) DeleteByIDs(ctx context.Context, ids []uuid.UUID, deletedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Model(&model.Departamento{}).
+		Where("id IN ?", ids).
+		Update("deleted_at", deletedAt).Error
 }
 
-func (r *departamentoRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&model.Departamento{}, "id = ?", id).Error
+// HardDelete removes the row for good. Nothing may still reference it,
+// soft-deleted rows included.
+func (r *departamentoRepository) HardDelete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Unscoped().Delete(&model.Departamento{}, "id = ?", id).Error
+}
+
+// DeleteAtVersion and HardDeleteAtVersion remove the row only if its version
+// still matches the one that was read, yielding ErrStaleVersion otherwise.
+func (r *departamentoRepository) DeleteAtVersion(ctx context.Context, id uuid.UUID, version int64) error {
+	return deleteAtVersion(r.db.WithContext(ctx), id, version)
+}
+
+func (r *departamentoRepository) HardDeleteAtVersion(ctx context.Context, id uuid.UUID, version int64) error {
+	return deleteAtVersion(r.db.WithContext(ctx).Unscoped(), id, version)
+}
+
+func deleteAtVersion(db *gorm.DB, id uuid.UUID, version int64) error {
+	result := db.Delete(&model.Departamento{}, "id = ? AND version = ?", id, version)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrStaleVersion
+	}
+	return nil
+}
+
+func (r *departamentoRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Unscoped().
+		Model(&model.Departamento{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Updates(map[string]interface{}{
+			"deleted_at": nil,
+			"version":    gorm.Expr("version + 1"),
+		}).Error
+}
+
+// PurgeResult counts the rows removed by DepartamentoRepository.Purge.
+type PurgeResult struct {
+	Departamentos int64
+	Colaboradores int64
+}
+
+// Purge hard-deletes departamentos soft-deleted before the cutoff together
+// with their colaboradores. A departamento qualifies only when it has no
+// subdepartamentos and every colaborador in it was also deleted before the
+// cutoff and manages no other department. Departamento and gerente reference
+// each other, so gerente_id is cleared first to let both rows go.
+func (r *departamentoRepository) Purge(ctx context.Context, deletedBefore time.Time) (PurgeResult, error) {
+	var result PurgeResult
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ids []uuid.UUID
+		err := tx.Raw(`
+			SELECT d.id
+			FROM departamentos d
+			WHERE d.deleted_at < $1
+			  AND NOT EXISTS (
+				SELECT 1 FROM departamentos sub WHERE sub.departamento_superior_id = d.id
+			  )
+			  AND NOT EXISTS (
+				SELECT 1 FROM colaboradores c
+				WHERE c.departamento_id = d.id
+				  AND (
+					c.deleted_at IS NULL
+					OR c.deleted_at >= $1
+					OR EXISTS (SELECT 1 FROM departamentos g WHERE g.gerente_id = c.id AND g.id <> d.id)
+				  )
+			  )
+		`, deletedBefore).Scan(&ids).Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		if err := tx.Exec("UPDATE departamentos SET gerente_id = NULL WHERE id IN ?", ids).Error; err != nil {
+			return err
+		}
+
+		colaboradores := tx.Unscoped().Where("departamento_id IN ?", ids).Delete(&model.Colaborador{})
+		if colaboradores.Error != nil {
+			return colaboradores.Error
+		}
+
+		departamentos := tx.Unscoped().Where("id IN ?", ids).Delete(&model.Departamento{})
+		if departamentos.Error != nil {
+			return departamentos.Error
+		}
+
+		result.Colaboradores = colaboradores.RowsAffected
+		result.Departamentos = departamentos.RowsAffected
+		return nil
+	})
+
+	return result, err
+}
+
+// GetChildren returns the direct subdepartamentos of id.
+func (r *departamentoRepository) GetChildren(ctx context.Context, id uuid.UUID) ([]model.Departamento, error) {
+	var children []model.Departamento
+	err := r.db.WithContext(ctx).
+		Where("departamento_superior_id = ?", id).
+		Order("nome").
+		Find(&children).Error
+	return children, err
+}
+
+func (r *departamentoRepository) GetByGerenteID(ctx context.Context, gerenteID uuid.UUID) ([]model.Departamento, error) {
+	var departamentos []model.Departamento
+	err := r.db.WithContext(ctx).
+		Where("gerente_id = ?", gerenteID).
+		Order("nome").
+		Find(&departamentos).Error
+	return departamentos, err
+}
+
+// GerenteViolation is an active departamento whose gerente is missing,
+// deleted or assigned to another departamento.
+type GerenteViolation struct {
+	DepartamentoID        uuid.UUID
+	DepartamentoNome      string
+	GerenteID             *uuid.UUID
+	GerenteDepartamentoID *uuid.UUID
+	Reason                string
+}
+
+// FindGerenteViolations checks the departamentos in departamentoIDs and those
+// managed by gerenteIDs. With both empty the whole table is checked.
+func (r *departamentoRepository) FindGerenteViolations(ctx context.Context, departamentoIDs, gerenteIDs []uuid.UUID) ([]GerenteViolation, error) {
+	query := r.db.WithContext(ctx).
+		Table("departamentos d").
+		Select(`d.id AS departamento_id, d.nome AS departamento_nome, d.gerente_id,
+			c.departamento_id AS gerente_departamento_id,
+			CASE
+				WHEN c.id IS NULL THEN 'gerente_missing'
+				WHEN c.deleted_at IS NOT NULL THEN 'gerente_deleted'
+				ELSE 'gerente_outside_departamento'
+			END AS reason`).
+		Joins("LEFT JOIN colaboradores c ON c.id = d.gerente_id").
+		Where("d.deleted_at IS NULL").
+		Where("c.id IS NULL OR c.deleted_at IS NOT NULL OR c.departamento_id <> d.id")
+
+	switch {
+	case len(departamentoIDs) > 0 && len(gerenteIDs) > 0:
+		query = query.Where("d.id IN ? OR d.gerente_id IN ?", departamentoIDs, gerenteIDs)
+	case len(departamentoIDs) > 0:
+		query = query.Where("d.id IN ?", departamentoIDs)
+	case len(gerenteIDs) > 0:
+		query = query.Where("d.gerente_id IN ?", gerenteIDs)
+	}
+
+	var violations []GerenteViolation
+	err := query.Order("d.nome").Scan(&violations).Error
+	return violations, err
+}
+
+// Reparent moves every direct subdepartamento of fromID under toID.
+// Soft-deleted ones follow too, so nothing keeps pointing at fromID.
+func (r *departamentoRepository) Reparent(ctx context.Context, fromID, toID uuid.UUID) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ids []uuid.UUID
+		err := tx.Unscoped().
+			Model(&model.Departamento{}).
+			Where("departamento_superior_id = ?", fromID).
+			Pluck("id", &ids).Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+		return setSuperior(tx.Unscoped(), ids, toID)
+	})
+	return translateError(err)
+}
+
+func (r *departamentoRepository) SetSuperior(ctx context.Context, ids []uuid.UUID, superiorID uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return setSuperior(tx, ids, superiorID)
+	})
+	return translateError(err)
+}
+
+func setSuperior(tx *gorm.DB, ids []uuid.UUID, superiorID uuid.UUID) error {
+	err := tx.
+		Model(&model.Departamento{}).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{
+			"departamento_superior_id": superiorID,
+			"version":                  gorm.Expr("version + 1"),
+		}).Error
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := relinkClosure(tx, id, &superiorID); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (r *departamentoRepository) List(ctx context.Context, filters map[string]interface{}, page, pageSize int) ([]model.Departamento, int64, error) {
@@ -131,6 +498,9 @@ func (r *departamentoRepository) List(ctx context.Context, filters map[string]in
 
 	query := r.db.WithContext(ctx).Model(&model.Departamento{})
 
+	if includeDeleted, ok := filters["include_deleted"].(bool); ok && includeDeleted {
+		query = query.Unscoped()
+	}
 	if nome, ok := filters["nome"].(string); ok && nome != "" {
 		query = query.Where("nome ILIKE ?", "%"+nome+"%")
 	}
@@ -155,41 +525,81 @@ func (r *departamentoRepository) List(ctx context.Context, filters map[string]in
 	return departamentos, total, err
 }
 
+// HasCycle reports whether superiorID is id itself or one of its
+// descendants, which would close a loop if it became id's superior.
 func (r *departamentoRepository) HasCycle(ctx context.Context, id, superiorID uuid.UUID) (bool, error) {
 	query := `
-		WITH RECURSIVE dept_hierarchy AS (
-			SELECT id, departamento_superior_id
-			FROM departamentos
-			WHERE id = $1
-			
-			UNION ALL
-			
-			SELECT d.id, d.departamento_superior_id
-			FROM departamentos d
-			INNER JOIN dept_hierarchy dh ON d.id = dh.departamento_superior_id
+		SELECT EXISTS (
+			SELECT 1 FROM departamento_closure
+			WHERE ancestor_id = $1 AND descendant_id = $2
 		)
-		SELECT EXISTS(SELECT 1 FROM dept_hierarchy WHERE id = $2)
 	`
 
 	var hasCycle bool
-	err := r.db.WithContext(ctx).Raw(query, superiorID, id).Scan(&hasCycle).Error
+	err := r.db.WithContext(ctx).Raw(query, id, superiorID).Scan(&hasCycle).Error
 	return hasCycle, err
 }
 
+// ancestorRow is a departamento read along with its depth, which the model
+// does not map to a column.
+type ancestorRow struct {
+	model.Departamento
+	AncestorDepth int
+}
+
+// GetAncestors returns the chain from the root down to id, id included,
+// with gerentes and depths filled in. It is empty when id is not active.
+// Each depth is read from the closure table, as GetDepth does, rather than
+// taken from the position in the chain.
+func (r *departamentoRepository) GetAncestors(ctx context.Context, id uuid.UUID) ([]model.Departamento, error) {
+	query := `
+		SELECT d.id, d.nome, d.gerente_id, d.departamento_superior_id,
+			(SELECT COALESCE(MAX(depth), 0) FROM departamento_closure WHERE descendant_id = d.id) AS ancestor_depth
+		FROM departamento_closure c
+		INNER JOIN departamentos d ON d.id = c.ancestor_id
+		WHERE c.descendant_id = $1
+			AND d.deleted_at IS NULL
+			AND EXISTS (SELECT 1 FROM departamentos WHERE id = $1 AND deleted_at IS NULL)
+		ORDER BY c.depth DESC
+	`
+
+	var rows []ancestorRow
+	if err := r.db.WithContext(ctx).Raw(query, id).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	ancestors := make([]model.Departamento, len(rows))
+	depts := make([]*model.Departamento, 0, len(rows))
+	for i := range rows {
+		depth := rows[i].AncestorDepth
+		ancestors[i] = rows[i].Departamento
+		ancestors[i].Depth = &depth
+		depts = append(depts, &ancestors[i])
+	}
+	if err := r.loadGerentes(ctx, depts); err != nil {
+		return nil, err
+	}
+
+	return ancestors, nil
+}
+
+// GetDepth counts the ancestors of id; a root has depth 0.
+func (r *departamentoRepository) GetDepth(ctx context.Context, id uuid.UUID) (int, error) {
+	query := `
+		SELECT COALESCE(MAX(depth), 0) FROM departamento_closure WHERE descendant_id = $1
+	`
+
+	var depth int
+	err := r.db.WithContext(ctx).Raw(query, id).Scan(&depth).Error
+	return depth, err
+}
+
 func (r *departamentoRepository) GetSubdepartamentosRecursive(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
 	query := `
-		WITH RECURSIVE subdepts AS (
-			SELECT id
-			FROM departamentos
-			WHERE departamento_superior_id = $1
-			
-			UNION ALL
-			
-			SELECT d.id
-			FROM departamentos d
-			INNER JOIN subdepts s ON d.departamento_superior_id = s.id
-		)
-		SELECT id FROM subdepts
+		SELECT c.descendant_id
+		FROM departamento_closure c
+		INNER JOIN departamentos d ON d.id = c.descendant_id
+		WHERE c.ancestor_id = $1 AND c.depth > 0 AND d.deleted_at IS NULL
 	`
 
 	var ids []uuid.UUID
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"errors"
+
+	"takehome-go/internal/apperror"
+)
+
+// ErrStaleVersion is returned by Update when the row was changed by someone
+// else since it was read, i.e. its version no longer matches.
+var ErrStaleVersion = errors.New("stale version")
+
+// sqlStateHierarchyCycle is raised by the departamentos_prevent_cycle
+// trigger (migration V7) when a write would close a loop.
+const sqlStateHierarchyCycle = "HC001"
+
+// translateError turns errors raised by database triggers into the domain
+// errors the services already return for the same rule.
+func translateError(err error) error {
+	var pgErr interface{ SQLState() string }
+	if errors.As(err, &pgErr) && pgErr.SQLState() == sqlStateHierarchyCycle {
+		return apperror.ErrHierarchyCycle
+	}
+	return err
+}
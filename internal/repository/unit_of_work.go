@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// Repos groups the repositories bound to the same transaction.
+type Repos struct {
+	Colaboradores ColaboradorRepository
+	Departamentos DepartamentoRepository
+}
+
+type UnitOfWork interface {
+	// WithTx runs fn inside a database transaction. Returning an error (or
+	// panicking) rolls back every write made through tx.
+	WithTx(ctx context.Context, fn func(tx Repos) error) error
+}
+
+type unitOfWork struct {
+	db *gorm.DB
+}
+
+func NewUnitOfWork(db *gorm.DB) UnitOfWork {
+	return &unitOfWork{db: db}
+}
+
+func (u *unitOfWork) WithTx(ctx context.Context, fn func(tx Repos) error) error {
+	return u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(Repos{
+			Colaboradores: NewColaboradorRepository(tx),
+			Departamentos: NewDepartamentoRepository(tx),
+		})
+	})
+}
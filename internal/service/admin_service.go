@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"takehome-go/internal/apperror"
+	"takehome-go/internal/database"
+	"takehome-go/internal/dto"
+	"takehome-go/internal/repository"
+)
+
+type AdminService interface {
+	Purge(ctx context.Context, retention time.Duration) (*dto.PurgeResponse, error)
+	ConsistencyReport(ctx context.Context) (*dto.ConsistencyReport, error)
+	WatchConsistency(ctx context.Context, interval time.Duration)
+}
+
+type adminService struct {
+	deptRepo repository.DepartamentoRepository
+	uow      repository.UnitOfWork
+	cache    database.Cache
+	logger   *zap.Logger
+}
+
+func NewAdminService(deptRepo repository.DepartamentoRepository, uow repository.UnitOfWork, cache database.Cache, logger *zap.Logger) AdminService {
+	return &adminService{
+		deptRepo: deptRepo,
+		uow:      uow,
+		cache:    cache,
+		logger:   logger,
+	}
+}
+
+// Purge permanently removes records soft-deleted longer ago than retention.
+// Departamentos go first since they take their colaboradores along; the
+// remaining colaboradores are purged individually afterwards.
+func (s *adminService) Purge(ctx context.Context, retention time.Duration) (*dto.PurgeResponse, error) {
+	deletedBefore := time.Now().Add(-retention)
+	s.logger.Info("Purging soft-deleted records", zap.Time("deleted_before", deletedBefore))
+
+	response := &dto.PurgeResponse{DeletedBefore: deletedBefore}
+
+	err := s.uow.WithTx(ctx, func(tx repository.Repos) error {
+		purged, err := tx.Departamentos.Purge(ctx, deletedBefore)
+		if err != nil {
+			s.logger.Error("Failed to purge departamentos", zap.Error(err))
+			return apperror.Internal("Erro ao expurgar departamentos", err)
+		}
+
+		colaboradores, err := tx.Colaboradores.Purge(ctx, deletedBefore)
+		if err != nil {
+			s.logger.Error("Failed to purge colaboradores", zap.Error(err))
+			return apperror.Internal("Erro ao expurgar colaboradores", err)
+		}
+
+		response.Departamentos = purged.Departamentos
+		response.Colaboradores = purged.Colaboradores + colaboradores
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Lists with include_deleted may show purged rows.
+	retireWriteStamp(ctx, s.cache)
+
+	s.logger.Info("Purge finished", zap.Int64("departamentos", response.Departamentos), zap.Int64("colaboradores", response.Colaboradores))
+	return response, nil
+}
+
+// ConsistencyReport scans every active departamento for a gerente that is
+// missing, deleted or working elsewhere.
+func (s *adminService) ConsistencyReport(ctx context.Context) (*dto.ConsistencyReport, error) {
+	violations, err := s.deptRepo.FindGerenteViolations(ctx, nil, nil)
+	if err != nil {
+		s.logger.Error("Failed to check gerente invariant", zap.Error(err))
+		return nil, apperror.Internal("Erro ao verificar consistência", err)
+	}
+
+	return &dto.ConsistencyReport{
+		CheckedAt:  time.Now(),
+		Total:      len(violations),
+		Violations: gerenteViolations(violations),
+	}, nil
+}
+
+// WatchConsistency runs ConsistencyReport every interval until ctx is done,
+// logging any violation found. A non-positive interval disables it.
+func (s *adminService) WatchConsistency(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			report, err := s.ConsistencyReport(ctx)
+			if err != nil {
+				continue
+			}
+			for _, v := range report.Violations {
+				s.logger.Warn("Gerente invariant violated",
+					zap.String("departamento_id", v.DepartamentoID.String()),
+					zap.String("reason", v.Reason))
+			}
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"takehome-go/internal/database"
+)
+
+// Filters understood by the repositories' List, with the type each expects.
+// Anything else has no effect on the result and is left out of the key.
+var (
+	colaboradorListFilters = map[string]filterKind{
+		"nome":            filterString,
+		"cpf":             filterString,
+		"rg":              filterString,
+		"departamento_id": filterString,
+		"include_deleted": filterBool,
+	}
+
+	departamentoListFilters = map[string]filterKind{
+		"nome":                     filterString,
+		"gerente_nome":             filterString,
+		"departamento_superior_id": filterString,
+		"include_deleted":          filterBool,
+	}
+)
+
+type filterKind int
+
+const (
+	filterString filterKind = iota
+	filterBool
+)
+
+// listCache caches one list endpoint. A zero TTL leaves it uncached. Lists
+// cannot be tagged by the rows they contain, since a write may add a row to
+// lists it was never part of, so their keys embed the write stamp instead:
+// any write retires it, and every list cached under the old stamp is never
+// read again and expires on its own.
+type listCache[T any] struct {
+	endpoint string
+	ttl      time.Duration
+	cache    database.Cache
+	reader   *cachedReader[T]
+	logger   *zap.Logger
+}
+
+func newListCache[T any](endpoint string, ttl time.Duration, cache database.Cache, opts CacheOptions, logger *zap.Logger) *listCache[T] {
+	readerOpts := CacheOptions{TTL: ttl, StaleTTL: opts.StaleTTL}
+	return &listCache[T]{
+		endpoint: endpoint,
+		ttl:      ttl,
+		cache:    cache,
+		reader:   newCachedReader[T](endpoint, cache, readerOpts, logger),
+		logger:   logger,
+	}
+}
+
+// Get returns the cached result for params, the normalized request, or
+// loads it. Without a stamp the cache cannot be trusted and is skipped.
+func (l *listCache[T]) Get(ctx context.Context, params interface{}, load func(ctx context.Context) (*T, error)) (*T, error) {
+	if l.ttl <= 0 {
+		return load(ctx)
+	}
+
+	stamp, err := writeStamp(ctx, l.cache)
+	if err != nil {
+		l.logger.Debug("List cache stamp unavailable", zap.String("endpoint", l.endpoint), zap.Error(err))
+		return load(ctx)
+	}
+	key, err := listKey(l.endpoint, stamp, params)
+	if err != nil {
+		return load(ctx)
+	}
+
+	return l.reader.Get(ctx, key, func(ctx context.Context) (*T, []string, bool, error) {
+		value, err := load(ctx)
+		return value, nil, true, err
+	})
+}
+
+func listKey(endpoint, stamp string, params interface{}) (string, error) {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return fmt.Sprintf("lista:%s:%s:%s", endpoint, stamp, hex.EncodeToString(sum[:16])), nil
+}
+
+// listParams is the normalized form of a List request.
+type listParams struct {
+	Filters  map[string]interface{} `json:"filters"`
+	Page     int                    `json:"page"`
+	PageSize int                    `json:"page_size"`
+}
+
+// normalizeListFilters keeps the filters the repository would apply, so
+// requests differing only in ignored or empty filters share an entry.
+// json.Marshal sorts map keys, which makes the key independent of order.
+func normalizeListFilters(filters map[string]interface{}, known map[string]filterKind) map[string]interface{} {
+	normalized := make(map[string]interface{})
+	for name, kind := range known {
+		switch kind {
+		case filterString:
+			if v, ok := filters[name].(string); ok && v != "" {
+				normalized[name] = v
+			}
+		case filterBool:
+			if v, ok := filters[name].(bool); ok && v {
+				normalized[name] = true
+			}
+		}
+	}
+	return normalized
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+
+	"takehome-go/internal/database"
+)
+
+var cacheRequestsTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "cache_requests_total",
+		Help: "Total number of cached reads by result (hit, stale, miss, coalesced)",
+	},
+	[]string{"entity", "result"},
+)
+
+// CacheOptions controls how long cached reads stay fresh. With StaleTTL set,
+// an expired value is still served for that long while a single background
+// load refreshes it. Writes drop entries through their tags, and a fill
+// racing with a write is discarded (see cachedReader.load), so a stale value
+// is one that aged out rather than one that was invalidated.
+//
+// List results are opt-in: each endpoint is cached only when its TTL is set.
+type CacheOptions struct {
+	TTL      time.Duration
+	StaleTTL time.Duration
+
+	ColaboradoresListTTL    time.Duration
+	DepartamentosListTTL    time.Duration
+	GerenteColaboradoresTTL time.Duration
+}
+
+// cachedEntry is what cachedReader stores: the value and when it stops
+// being fresh.
+type cachedEntry[T any] struct {
+	Value      *T        `json:"value"`
+	FreshUntil time.Time `json:"fresh_until"`
+}
+
+// cacheLoader reads a value from the database along with the tags it must
+// be cached under, and whether it may be cached at all.
+type cacheLoader[T any] func(ctx context.Context) (value *T, tags []string, cacheable bool, err error)
+
+// cachedReader is a cache-aside reader that lets only one load per key run
+// at a time: concurrent misses wait for it and share its result.
+type cachedReader[T any] struct {
+	entity string
+	cache  database.Cache
+	opts   CacheOptions
+	group  singleflight.Group
+	logger *zap.Logger
+}
+
+func newCachedReader[T any](entity string, cache database.Cache, opts CacheOptions, logger *zap.Logger) *cachedReader[T] {
+	return &cachedReader[T]{entity: entity, cache: cache, opts: opts, logger: logger}
+}
+
+// Get returns the value under key, loading it on a miss. The shared load
+// runs detached from the caller's cancellation so one client going away
+// does not fail everyone waiting on it.
+func (r *cachedReader[T]) Get(ctx context.Context, key string, load cacheLoader[T]) (*T, error) {
+	var entry cachedEntry[T]
+	if err := r.cache.Get(ctx, key, &entry); err == nil && entry.Value != nil {
+		if time.Now().Before(entry.FreshUntil) {
+			cacheRequestsTotal.WithLabelValues(r.entity, "hit").Inc()
+			return entry.Value, nil
+		}
+		if r.opts.StaleTTL > 0 {
+			cacheRequestsTotal.WithLabelValues(r.entity, "stale").Inc()
+			r.group.DoChan(key, func() (interface{}, error) {
+				return r.load(context.WithoutCancel(ctx), key, load)
+			})
+			return entry.Value, nil
+		}
+	}
+
+	value, err, shared := r.group.Do(key, func() (interface{}, error) {
+		return r.load(context.WithoutCancel(ctx), key, load)
+	})
+	if shared {
+		cacheRequestsTotal.WithLabelValues(r.entity, "coalesced").Inc()
+	} else {
+		cacheRequestsTotal.WithLabelValues(r.entity, "miss").Inc()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return value.(*T), nil
+}
+
+// load reads the value and caches it. A write may commit and invalidate its
+// tags after the database read but before the Set, which would leave the
+// old value cached, so the write stamp is read before loading and checked
+// again after the Set: if any write retired it meanwhile, the entry just
+// written is deleted. Writes retire the stamp before invalidating tags, so
+// either the write's invalidation comes after the Set and removes the entry
+// or the check sees the new stamp. The old value can only be read in the
+// moment between the Set and that Delete. With the tiered backend the check
+// may read a local copy of the stamp that other instances' writes have not
+// reached yet, so across instances the race is only narrowed, not closed.
+func (r *cachedReader[T]) load(ctx context.Context, key string, load cacheLoader[T]) (*T, error) {
+	stamp, stampErr := writeStamp(ctx, r.cache)
+
+	value, tags, cacheable, err := load(ctx)
+	if err != nil || !cacheable || stampErr != nil {
+		return value, err
+	}
+
+	ttl := jitter(r.opts.TTL)
+	entry := cachedEntry[T]{Value: value, FreshUntil: time.Now().Add(ttl)}
+	if err := r.cache.Set(ctx, key, entry, ttl+r.opts.StaleTTL, tags...); err != nil {
+		r.logger.Debug("Failed to cache value", zap.String("key", key), zap.Error(err))
+		return value, nil
+	}
+
+	var current string
+	if err := r.cache.Get(ctx, writeStampKey, &current); err != nil || current != stamp {
+		r.logger.Debug("Discarding value loaded during a write", zap.String("key", key))
+		r.cache.Delete(ctx, key)
+	}
+	return value, nil
+}
+
+// writeStampKey holds a token that every write retires. Cached lists embed
+// it in their keys, and cachedReader compares it around each fill to detect
+// writes that raced with the load.
+const writeStampKey = "stamp:escritas"
+
+// writeStamp returns the current stamp, minting one if there is none.
+func writeStamp(ctx context.Context, cache database.Cache) (string, error) {
+	var stamp string
+	if err := cache.Get(ctx, writeStampKey, &stamp); err == nil && stamp != "" {
+		return stamp, nil
+	}
+	stamp = uuid.NewString()
+	if err := cache.Set(ctx, writeStampKey, stamp, 0); err != nil {
+		return "", err
+	}
+	return stamp, nil
+}
+
+// retireWriteStamp marks that a write happened. It goes through Delete so
+// the breaker retries it like any other invalidation, and it must run
+// before the write's InvalidateTags for cachedReader.load to be safe.
+func retireWriteStamp(ctx context.Context, cache database.Cache) {
+	cache.Delete(ctx, writeStampKey)
+}
+
+// jitter spreads ttl by ±10% so entries written together do not all expire
+// at the same instant.
+func jitter(ttl time.Duration) time.Duration {
+	if ttl <= 0 {
+		return ttl
+	}
+	spread := ttl / 5
+	return ttl - spread/2 + rand.N(spread+1)
+}
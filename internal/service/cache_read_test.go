@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"takehome-go/internal/database"
+)
+
+type cachedValue struct {
+	Nome string `json:"nome"`
+}
+
+// countingCache counts reads of one key, to know when callers reached it.
+type countingCache struct {
+	*database.MemoryCache
+	key  string
+	gets atomic.Int32
+}
+
+func (c *countingCache) Get(ctx context.Context, key string, dest interface{}) error {
+	if key == c.key {
+		c.gets.Add(1)
+	}
+	return c.MemoryCache.Get(ctx, key, dest)
+}
+
+func TestCachedReaderCoalescesMisses(t *testing.T) {
+	const callers = 8
+	ctx := context.Background()
+	cache := &countingCache{MemoryCache: database.NewMemoryCache(0), key: "k"}
+	reader := newCachedReader[cachedValue]("test", cache, CacheOptions{TTL: time.Minute}, zap.NewNop())
+
+	var loads atomic.Int32
+	release := make(chan struct{})
+	load := func(context.Context) (*cachedValue, []string, bool, error) {
+		loads.Add(1)
+		<-release
+		return &cachedValue{Nome: "TI"}, nil, true, nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]*cachedValue, callers)
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := reader.Get(ctx, "k", load)
+			if err != nil {
+				t.Errorf("get: %v", err)
+			}
+			results[i] = value
+		}()
+	}
+
+	// Every caller missed the cache; give the last ones time to join the
+	// load in flight before letting it finish.
+	for cache.gets.Load() < callers {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := loads.Load(); got != 1 {
+		t.Fatalf("loads = %d, want 1", got)
+	}
+	for i, value := range results {
+		if value == nil || value.Nome != "TI" {
+			t.Fatalf("caller %d got %+v", i, value)
+		}
+	}
+}
+
+func TestCachedReaderDropsFillRacingWithWrite(t *testing.T) {
+	tests := []struct {
+		name            string
+		writeDuringLoad bool
+		wantCached      bool
+	}{
+		{name: "no write", wantCached: true},
+		{name: "write during load", writeDuringLoad: true, wantCached: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			cache := database.NewMemoryCache(0)
+			reader := newCachedReader[cachedValue]("test", cache, CacheOptions{TTL: time.Minute}, zap.NewNop())
+
+			load := func(ctx context.Context) (*cachedValue, []string, bool, error) {
+				if tt.writeDuringLoad {
+					// A write commits after the database read and before
+					// the value is cached.
+					retireWriteStamp(ctx, cache)
+				}
+				return &cachedValue{Nome: "TI"}, []string{"departamento:1"}, true, nil
+			}
+
+			value, err := reader.Get(ctx, "k", load)
+			if err != nil {
+				t.Fatalf("get: %v", err)
+			}
+			if value.Nome != "TI" {
+				t.Fatalf("value = %+v, want the loaded value returned", value)
+			}
+
+			var entry cachedEntry[cachedValue]
+			cached := cache.Get(ctx, "k", &entry) == nil
+			if cached != tt.wantCached {
+				t.Fatalf("cached = %v, want %v", cached, tt.wantCached)
+			}
+		})
+	}
+}
+
+func TestCachedReaderStaleWhileRevalidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		staleTTL time.Duration
+		want     string
+	}{
+		{name: "stale value served while refreshing", staleTTL: time.Minute, want: "antigo"},
+		{name: "without stale ttl the caller waits for the load", staleTTL: 0, want: "novo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			cache := database.NewMemoryCache(0)
+			reader := newCachedReader[cachedValue]("test", cache, CacheOptions{TTL: time.Minute, StaleTTL: tt.staleTTL}, zap.NewNop())
+
+			expired := cachedEntry[cachedValue]{Value: &cachedValue{Nome: "antigo"}, FreshUntil: time.Now().Add(-time.Second)}
+			if err := cache.Set(ctx, "k", expired, time.Hour); err != nil {
+				t.Fatalf("set: %v", err)
+			}
+
+			var loads atomic.Int32
+			load := func(context.Context) (*cachedValue, []string, bool, error) {
+				loads.Add(1)
+				return &cachedValue{Nome: "novo"}, nil, true, nil
+			}
+
+			value, err := reader.Get(ctx, "k", load)
+			if err != nil {
+				t.Fatalf("get: %v", err)
+			}
+			if value.Nome != tt.want {
+				t.Fatalf("value = %q, want %q", value.Nome, tt.want)
+			}
+
+			// Either way the entry ends up refreshed, by the caller or in
+			// the background.
+			deadline := time.Now().Add(time.Second)
+			for {
+				var entry cachedEntry[cachedValue]
+				if cache.Get(ctx, "k", &entry) == nil && entry.Value.Nome == "novo" && time.Now().Before(entry.FreshUntil) {
+					break
+				}
+				if time.Now().After(deadline) {
+					t.Fatal("entry was not refreshed")
+				}
+				time.Sleep(time.Millisecond)
+			}
+			if got := loads.Load(); got != 1 {
+				t.Fatalf("loads = %d, want 1", got)
+			}
+		})
+	}
+}
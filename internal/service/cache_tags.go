@@ -0,0 +1,55 @@
+package service
+
+import (
+	"github.com/google/uuid"
+
+	"takehome-go/internal/model"
+)
+
+// Cached values are tagged with every record they were built from, using
+// these names, so a write only has to invalidate the tags of the records it
+// touched:
+//
+//   - departamento:<id> is carried by the cached tree of the departamento,
+//     of each of its ancestors (their trees embed it) and of each of its
+//     descendants (their depth depends on it), and by its colaboradores
+//     (they show its gerente's name).
+//   - colaborador:<id> is carried by the cached colaborador, by every tree
+//     where it appears as gerente and by the colaboradores it manages.
+func departamentoTag(id uuid.UUID) string {
+	return "departamento:" + id.String()
+}
+
+func colaboradorTag(id uuid.UUID) string {
+	return "colaborador:" + id.String()
+}
+
+// departamentoTreeTags tags a cached tree with its ancestors, every node of
+// the subtree and every gerente shown in it.
+func departamentoTreeTags(tree *model.Departamento, ancestors []model.Departamento) []string {
+	var tags []string
+	for _, a := range ancestors {
+		if a.ID != tree.ID {
+			tags = append(tags, departamentoTag(a.ID))
+		}
+	}
+
+	var walk func(d *model.Departamento)
+	walk = func(d *model.Departamento) {
+		tags = append(tags, departamentoTag(d.ID), colaboradorTag(d.GerenteID))
+		for i := range d.Subdepartamentos {
+			walk(&d.Subdepartamentos[i])
+		}
+	}
+	walk(tree)
+	return tags
+}
+
+// superiorTags returns the tag of the departamento's superior, if any, whose
+// tree gains or loses a child.
+func superiorTags(superiorID *uuid.UUID) []string {
+	if superiorID == nil {
+		return nil
+	}
+	return []string{departamentoTag(*superiorID)}
+}
@@ -4,44 +4,53 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"time"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 
+	"takehome-go/internal/apperror"
 	"takehome-go/internal/database"
 	"takehome-go/internal/dto"
 	"takehome-go/internal/model"
 	"takehome-go/internal/repository"
-	"takehome-go/internal/validator"
 )
 
 type ColaboradorService interface {
 	Create(ctx context.Context, req *dto.CreateColaboradorRequest) (*model.Colaborador, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*dto.ColaboradorResponse, error)
-	Update(ctx context.Context, id uuid.UUID, req *dto.UpdateColaboradorRequest) (*model.Colaborador, error)
-	Delete(ctx context.Context, id uuid.UUID) error
+	Update(ctx context.Context, id uuid.UUID, req *dto.UpdateColaboradorRequest, expectedVersion *int64) (*model.Colaborador, error)
+	Patch(ctx context.Context, id uuid.UUID, req *dto.PatchColaboradorRequest, expectedVersion *int64) (*model.Colaborador, error)
+	Delete(ctx context.Context, id uuid.UUID, opts *dto.DeleteColaboradorOptions) error
+	Restore(ctx context.Context, id uuid.UUID) (*model.Colaborador, error)
 	List(ctx context.Context, filters map[string]interface{}, page, pageSize int) (*dto.ListColaboradoresResponse, error)
 }
 
 type colaboradorService struct {
 	repo     repository.ColaboradorRepository
 	deptRepo repository.DepartamentoRepository
+	uow      repository.UnitOfWork
 	cache    database.Cache
+	byID     *cachedReader[dto.ColaboradorResponse]
+	lists    *listCache[dto.ListColaboradoresResponse]
 	logger   *zap.Logger
 }
 
 func NewColaboradorService(
 	repo repository.ColaboradorRepository,
 	deptRepo repository.DepartamentoRepository,
+	uow repository.UnitOfWork,
 	cache database.Cache,
+	cacheOpts CacheOptions,
 	logger *zap.Logger,
 ) ColaboradorService {
 	return &colaboradorService{
 		repo:     repo,
 		deptRepo: deptRepo,
+		uow:      uow,
 		cache:    cache,
+		byID:     newCachedReader[dto.ColaboradorResponse]("colaborador", cache, cacheOpts, logger),
+		lists:    newListCache[dto.ListColaboradoresResponse]("colaboradores", cacheOpts.ColaboradoresListTTL, cache, cacheOpts, logger),
 		logger:   logger,
 	}
 }
@@ -49,46 +58,8 @@ func NewColaboradorService(
 func (s *colaboradorService) Create(ctx context.Context, req *dto.CreateColaboradorRequest) (*model.Colaborador, error) {
 	s.logger.Info("Creating colaborador", zap.String("nome", req.Nome), zap.String("cpf", req.CPF))
 
-	if !validator.ValidateCPF(req.CPF) {
-		s.logger.Warn("Invalid CPF provided", zap.String("cpf", req.CPF))
-		return nil, errors.New("CPF inválido")
-	}
-
-	exists, err := s.repo.ExistsByCPF(ctx, req.CPF, nil)
-	if err != nil {
-		s.logger.Error("Failed to check CPF existence", zap.Error(err))
-		return nil, errors.New("Erro ao verificar CPF")
-	}
-	if exists {
-		s.logger.Warn("CPF already exists", zap.String("cpf", req.CPF))
-		return nil, errors.New("CPF já cadastrado")
-	}
-
-	if req.RG != nil && *req.RG != "" {
-		if !validator.ValidateRG(*req.RG) {
-			s.logger.Warn("Invalid RG provided", zap.String("rg", *req.RG))
-			return nil, errors.New("RG inválido")
-		}
-
-		exists, err := s.repo.ExistsByRG(ctx, *req.RG, nil)
-		if err != nil {
-			s.logger.Error("Failed to check RG existence", zap.Error(err))
-			return nil, errors.New("Erro ao verificar RG")
-		}
-		if exists {
-			s.logger.Warn("RG already exists", zap.String("rg", *req.RG))
-			return nil, errors.New("RG já cadastrado")
-		}
-	}
-
-	_, err = s.deptRepo.GetByID(ctx, req.DepartamentoID)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			s.logger.Warn("Department not found", zap.String("departamento_id", req.DepartamentoID.String()))
-			return nil, errors.New("Departamento não encontrado")
-		}
-		s.logger.Error("Failed to get department", zap.Error(err))
-		return nil, errors.New("Erro ao buscar departamento")
+	if err := s.validateCreate(ctx, req); err != nil {
+		return nil, err
 	}
 
 	colaborador := &model.Colaborador{
@@ -100,9 +71,11 @@ func (s *colaboradorService) Create(ctx context.Context, req *dto.CreateColabora
 
 	if err := s.repo.Create(ctx, colaborador); err != nil {
 		s.logger.Error("Failed to create colaborador", zap.Error(err))
-		return nil, errors.New("Erro ao criar colaborador")
+		return nil, apperror.Internal("Erro ao criar colaborador", err)
 	}
 
+	retireWriteStamp(ctx, s.cache)
+
 	s.logger.Info("Colaborador created successfully", zap.String("id", colaborador.ID.String()))
 	return colaborador, nil
 }
@@ -111,20 +84,28 @@ func (s *colaboradorService) GetByID(ctx context.Context, id uuid.UUID) (*dto.Co
 	s.logger.Info("Getting colaborador by ID", zap.String("id", id.String()))
 
 	cacheKey := fmt.Sprintf("colaborador:%s", id.String())
-	var cached dto.ColaboradorResponse
-	if err := s.cache.Get(ctx, cacheKey, &cached); err == nil {
-		s.logger.Debug("Colaborador found in cache", zap.String("id", id.String()))
-		return &cached, nil
+	response, err := s.byID.Get(ctx, cacheKey, func(ctx context.Context) (*dto.ColaboradorResponse, []string, bool, error) {
+		return s.loadColaborador(ctx, id)
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	s.logger.Info("Colaborador retrieved successfully", zap.String("id", id.String()))
+	return response, nil
+}
+
+// loadColaborador builds the GetByID response from the database, tagged
+// with the colaborador, its departamento and the gerente it shows.
+func (s *colaboradorService) loadColaborador(ctx context.Context, id uuid.UUID) (*dto.ColaboradorResponse, []string, bool, error) {
 	colaborador, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			s.logger.Warn("Colaborador not found", zap.String("id", id.String()))
-			return nil, errors.New("Colaborador não encontrado")
+			return nil, nil, false, apperror.ErrColaboradorNotFound
 		}
 		s.logger.Error("Failed to get colaborador", zap.Error(err))
-		return nil, errors.New("Erro ao buscar colaborador")
+		return nil, nil, false, apperror.Internal("Erro ao buscar colaborador", err)
 	}
 
 	response := &dto.ColaboradorResponse{
@@ -133,120 +114,222 @@ func (s *colaboradorService) GetByID(ctx context.Context, id uuid.UUID) (*dto.Co
 		CPF:            colaborador.CPF,
 		RG:             colaborador.RG,
 		DepartamentoID: colaborador.DepartamentoID,
+		Version:        colaborador.Version,
 		CreatedAt:      colaborador.CreatedAt,
 		UpdatedAt:      colaborador.UpdatedAt,
 	}
 
+	tags := []string{colaboradorTag(id), departamentoTag(colaborador.DepartamentoID)}
 	if colaborador.Departamento != nil && colaborador.Departamento.Gerente != nil {
 		response.NomeGerente = colaborador.Departamento.Gerente.Nome
+		tags = append(tags, colaboradorTag(colaborador.Departamento.Gerente.ID))
 	}
-
-	s.cache.Set(ctx, cacheKey, response, 5*time.Minute)
-	s.logger.Info("Colaborador retrieved successfully", zap.String("id", id.String()))
-
-	return response, nil
+	return response, tags, true, nil
 }
 
-func (s *colaboradorService) Update(ctx context.Context, id uuid.UUID, req *dto.UpdateColaboradorRequest) (*model.Colaborador, error) {
+func (s *colaboradorService) Update(ctx context.Context, id uuid.UUID, req *dto.UpdateColaboradorRequest, expectedVersion *int64) (*model.Colaborador, error) {
 	s.logger.Info("Updating colaborador", zap.String("id", id.String()))
+	return s.applyPatch(ctx, id, req.ToPatch(), expectedVersion)
+}
 
+func (s *colaboradorService) Patch(ctx context.Context, id uuid.UUID, req *dto.PatchColaboradorRequest, expectedVersion *int64) (*model.Colaborador, error) {
+	s.logger.Info("Patching colaborador", zap.String("id", id.String()))
+	return s.applyPatch(ctx, id, req, expectedVersion)
+}
+
+// applyPatch is shared by PUT and PATCH; PUT requests are converted to a
+// patch first so both go through the same validations.
+func (s *colaboradorService) applyPatch(ctx context.Context, id uuid.UUID, req *dto.PatchColaboradorRequest, expectedVersion *int64) (*model.Colaborador, error) {
 	colaborador, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			s.logger.Warn("Colaborador not found", zap.String("id", id.String()))
-			return nil, errors.New("Colaborador não encontrado")
+			return nil, apperror.ErrColaboradorNotFound
 		}
 		s.logger.Error("Failed to get colaborador", zap.Error(err))
-		return nil, errors.New("Erro ao buscar colaborador")
+		return nil, apperror.Internal("Erro ao buscar colaborador", err)
+	}
+
+	if err := checkVersion(expectedVersion, colaborador.Version); err != nil {
+		s.logger.Warn("Colaborador version mismatch", zap.String("id", id.String()), zap.Int64("version", colaborador.Version))
+		return nil, err
 	}
 
-	if req.Nome != "" {
-		colaborador.Nome = req.Nome
+	if err := s.validatePatch(ctx, id, req); err != nil {
+		return nil, err
 	}
 
-	if req.CPF != "" {
-		if !validator.ValidateCPF(req.CPF) {
-			s.logger.Warn("Invalid CPF provided", zap.String("cpf", req.CPF))
-			return nil, errors.New("CPF inválido")
+	if req.Nome.Set {
+		colaborador.Nome = req.Nome.Value
+	}
+	if req.CPF.Set {
+		colaborador.CPF = req.CPF.Value
+	}
+	if req.RG.Set {
+		if req.RG.Null {
+			colaborador.RG = nil
+		} else {
+			rg := req.RG.Value
+			colaborador.RG = &rg
 		}
-		exists, err := s.repo.ExistsByCPF(ctx, req.CPF, &id)
+	}
+	if req.DepartamentoID.Set {
+		colaborador.DepartamentoID = req.DepartamentoID.Value
+	}
+
+	err = s.uow.WithTx(ctx, func(tx repository.Repos) error {
+		existing, err := existingGerenteViolations(ctx, tx.Departamentos, s.logger, nil, []uuid.UUID{id})
 		if err != nil {
-			s.logger.Error("Failed to check CPF existence", zap.Error(err))
-			return nil, errors.New("Erro ao verificar CPF")
+			return err
 		}
-		if exists {
-			s.logger.Warn("CPF already exists", zap.String("cpf", req.CPF))
-			return nil, errors.New("CPF já cadastrado")
+
+		if err := tx.Colaboradores.Update(ctx, colaborador); err != nil {
+			if staleErr := staleVersionError(err, expectedVersion); staleErr != nil {
+				s.logger.Warn("Colaborador changed concurrently", zap.String("id", id.String()))
+				return staleErr
+			}
+			s.logger.Error("Failed to update colaborador", zap.Error(err))
+			return apperror.Internal("Erro ao atualizar colaborador", err)
 		}
-		colaborador.CPF = req.CPF
+		return enforceGerenteInvariant(ctx, tx.Departamentos, s.logger, existing, nil, []uuid.UUID{id})
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	if req.RG != nil && *req.RG != "" {
-		if !validator.ValidateRG(*req.RG) {
-			s.logger.Warn("Invalid RG provided", zap.String("rg", *req.RG))
-			return nil, errors.New("RG inválido")
+	// Also drops the trees and colaboradores showing this one as gerente.
+	s.invalidate(ctx, colaboradorTag(id))
+
+	s.logger.Info("Colaborador updated successfully", zap.String("id", id.String()))
+	return colaborador, nil
+}
+
+// Delete removes a colaborador. One who manages departments is only removed
+// when opts names a successor for each of them, who takes over in the same
+// transaction as the delete.
+func (s *colaboradorService) Delete(ctx context.Context, id uuid.UUID, opts *dto.DeleteColaboradorOptions) error {
+	s.logger.Info("Deleting colaborador", zap.String("id", id.String()))
+
+	_, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			s.logger.Warn("Colaborador not found", zap.String("id", id.String()))
+			return apperror.ErrColaboradorNotFound
 		}
+		s.logger.Error("Failed to get colaborador", zap.Error(err))
+		return apperror.Internal("Erro ao buscar colaborador", err)
+	}
 
-		exists, err := s.repo.ExistsByRG(ctx, *req.RG, &id)
+	// The managed departments and the successor are read inside the
+	// transaction, and the versioned Update below rejects a departamento
+	// that changed after it was read instead of handing it over stale.
+	var managed []model.Departamento
+	err = s.uow.WithTx(ctx, func(tx repository.Repos) error {
+		var err error
+		managed, err = tx.Departamentos.GetByGerenteID(ctx, id)
 		if err != nil {
-			s.logger.Error("Failed to check RG existence", zap.Error(err))
-			return nil, errors.New("Erro ao verificar RG")
+			s.logger.Error("Failed to get managed departments", zap.Error(err))
+			return apperror.Internal("Erro ao buscar departamentos gerenciados", err)
 		}
-		if exists {
-			s.logger.Warn("RG already exists", zap.String("rg", *req.RG))
-			return nil, errors.New("RG já cadastrado")
+
+		successors, err := s.validateSuccessors(ctx, tx.Colaboradores, id, opts, managed)
+		if err != nil {
+			return err
 		}
-		colaborador.RG = req.RG
-	}
 
-	if req.DepartamentoID != nil {
-		_, err := s.deptRepo.GetByID(ctx, *req.DepartamentoID)
+		existing, err := existingGerenteViolations(ctx, tx.Departamentos, s.logger, nil, []uuid.UUID{id})
 		if err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				s.logger.Warn("Department not found", zap.String("departamento_id", req.DepartamentoID.String()))
-				return nil, errors.New("Departamento não encontrado")
+			return err
+		}
+
+		for i := range managed {
+			managed[i].GerenteID = successors[managed[i].ID]
+			if err := tx.Departamentos.Update(ctx, &managed[i]); err != nil {
+				if staleErr := staleVersionError(err, nil); staleErr != nil {
+					s.logger.Warn("Departamento changed during succession", zap.String("departamento_id", managed[i].ID.String()))
+					return staleErr
+				}
+				s.logger.Error("Failed to transfer gerente", zap.Error(err))
+				return apperror.Internal("Erro ao transferir gerência", err)
 			}
-			s.logger.Error("Failed to get department", zap.Error(err))
-			return nil, errors.New("Erro ao buscar departamento")
 		}
-		colaborador.DepartamentoID = *req.DepartamentoID
-	}
 
-	if err := s.repo.Update(ctx, colaborador); err != nil {
-		s.logger.Error("Failed to update colaborador", zap.Error(err))
-		return nil, errors.New("Erro ao atualizar colaborador")
+		if err := tx.Colaboradores.Delete(ctx, id); err != nil {
+			s.logger.Error("Failed to delete colaborador", zap.Error(err))
+			return apperror.Internal("Erro ao deletar colaborador", err)
+		}
+		return enforceGerenteInvariant(ctx, tx.Departamentos, s.logger, existing, nil, []uuid.UUID{id})
+	})
+	if err != nil {
+		return err
 	}
 
-	cacheKey := fmt.Sprintf("colaborador:%s", id.String())
-	s.cache.Delete(ctx, cacheKey)
+	// Covers the departments handed to the successor and their colaboradores,
+	// which were all tagged with the outgoing gerente.
+	s.invalidate(ctx, colaboradorTag(id))
 
-	s.logger.Info("Colaborador updated successfully", zap.String("id", id.String()))
-	return colaborador, nil
+	s.logger.Info("Colaborador deleted successfully", zap.String("id", id.String()), zap.Int("departamentos_transferred", len(managed)))
+	return nil
 }
 
-func (s *colaboradorService) Delete(ctx context.Context, id uuid.UUID) error {
-	s.logger.Info("Deleting colaborador", zap.String("id", id.String()))
+// gerenteError lists the managed departments still without a successor.
+func (s *colaboradorService) gerenteError(id uuid.UUID, managed []model.Departamento) error {
+	s.logger.Warn("Colaborador manages departments", zap.String("id", id.String()), zap.Int("departamentos", len(managed)))
+	return apperror.ErrColaboradorIsGerente.WithDetails(colaboradorDependents(managed))
+}
 
-	_, err := s.repo.GetByID(ctx, id)
+func colaboradorDependents(departamentos []model.Departamento) dto.ColaboradorDependents {
+	dependents := dto.ColaboradorDependents{
+		Departamentos: make([]dto.DependentSummary, 0, len(departamentos)),
+	}
+	for _, d := range departamentos {
+		dependents.Departamentos = append(dependents.Departamentos, dto.DependentSummary{ID: d.ID, Nome: d.Nome})
+	}
+	return dependents
+}
+
+// Restore undoes a soft delete. The colaborador's departamento must be
+// active, otherwise it would come back attached to a removed department.
+// Restoring an active colaborador is a no-op.
+func (s *colaboradorService) Restore(ctx context.Context, id uuid.UUID) (*model.Colaborador, error) {
+	s.logger.Info("Restoring colaborador", zap.String("id", id.String()))
+
+	colaborador, err := s.repo.GetByIDUnscoped(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			s.logger.Warn("Colaborador not found", zap.String("id", id.String()))
-			return errors.New("Colaborador não encontrado")
+			return nil, apperror.ErrColaboradorNotFound
 		}
 		s.logger.Error("Failed to get colaborador", zap.Error(err))
-		return errors.New("Erro ao buscar colaborador")
+		return nil, apperror.Internal("Erro ao buscar colaborador", err)
 	}
 
-	if err := s.repo.Delete(ctx, id); err != nil {
-		s.logger.Error("Failed to delete colaborador", zap.Error(err))
-		return errors.New("Erro ao deletar colaborador")
+	if colaborador.DeletedAt.Valid {
+		if _, err := s.deptRepo.GetByID(ctx, colaborador.DepartamentoID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				s.logger.Warn("Colaborador department is deleted", zap.String("departamento_id", colaborador.DepartamentoID.String()))
+				return nil, apperror.ErrDepartamentoDeleted
+			}
+			s.logger.Error("Failed to get department", zap.Error(err))
+			return nil, apperror.Internal("Erro ao buscar departamento", err)
+		}
+
+		if err := s.repo.Restore(ctx, id); err != nil {
+			s.logger.Error("Failed to restore colaborador", zap.Error(err))
+			return nil, apperror.Internal("Erro ao restaurar colaborador", err)
+		}
 	}
 
-	cacheKey := fmt.Sprintf("colaborador:%s", id.String())
-	s.cache.Delete(ctx, cacheKey)
+	restored, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("Failed to get colaborador", zap.Error(err))
+		return nil, apperror.Internal("Erro ao buscar colaborador", err)
+	}
 
-	s.logger.Info("Colaborador deleted successfully", zap.String("id", id.String()))
-	return nil
+	s.invalidate(ctx, colaboradorTag(id))
+
+	s.logger.Info("Colaborador restored successfully", zap.String("id", id.String()))
+	return restored, nil
 }
 
 func (s *colaboradorService) List(ctx context.Context, filters map[string]interface{}, page, pageSize int) (*dto.ListColaboradoresResponse, error) {
@@ -259,24 +342,34 @@ func (s *colaboradorService) List(ctx context.Context, filters map[string]interf
 		pageSize = 10
 	}
 
-	colaboradores, total, err := s.repo.List(ctx, filters, page, pageSize)
-	if err != nil {
-		s.logger.Error("Failed to list colaboradores", zap.Error(err))
-		return nil, errors.New("Erro ao listar colaboradores")
-	}
+	params := listParams{Filters: normalizeListFilters(filters, colaboradorListFilters), Page: page, PageSize: pageSize}
+	return s.lists.Get(ctx, params, func(ctx context.Context) (*dto.ListColaboradoresResponse, error) {
+		colaboradores, total, err := s.repo.List(ctx, filters, page, pageSize)
+		if err != nil {
+			s.logger.Error("Failed to list colaboradores", zap.Error(err))
+			return nil, apperror.Internal("Erro ao listar colaboradores", err)
+		}
 
-	totalPages := int(total) / pageSize
-	if int(total)%pageSize > 0 {
-		totalPages++
-	}
+		totalPages := int(total) / pageSize
+		if int(total)%pageSize > 0 {
+			totalPages++
+		}
 
-	s.logger.Info("Colaboradores listed successfully", zap.Int64("total", total))
+		s.logger.Info("Colaboradores listed successfully", zap.Int64("total", total))
+
+		return &dto.ListColaboradoresResponse{
+			Data:       colaboradores,
+			Total:      total,
+			Page:       page,
+			PageSize:   pageSize,
+			TotalPages: totalPages,
+		}, nil
+	})
+}
 
-	return &dto.ListColaboradoresResponse{
-		Data:       colaboradores,
-		Total:      total,
-		Page:       page,
-		PageSize:   pageSize,
-		TotalPages: totalPages,
-	}, nil
+// invalidate retires the write stamp, dropping every cached list, then the
+// cached entries carrying tags.
+func (s *colaboradorService) invalidate(ctx context.Context, tags ...string) {
+	retireWriteStamp(ctx, s.cache)
+	s.cache.InvalidateTags(ctx, tags...)
 }
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"takehome-go/internal/apperror"
+	"takehome-go/internal/dto"
+	"takehome-go/internal/model"
+	"takehome-go/internal/repository"
+	"takehome-go/internal/validator"
+)
+
+// validateCreate evaluates every rule for a new colaborador and returns all
+// violations together. Only infrastructure failures short-circuit.
+func (s *colaboradorService) validateCreate(ctx context.Context, req *dto.CreateColaboradorRequest) error {
+	v := apperror.NewViolations()
+
+	if err := checkCPF(ctx, s.repo, s.logger, v, req.CPF, nil); err != nil {
+		return err
+	}
+	if req.RG != nil && *req.RG != "" {
+		if err := checkRG(ctx, s.repo, s.logger, v, *req.RG, nil); err != nil {
+			return err
+		}
+	}
+	if err := s.checkDepartamento(ctx, v, req.DepartamentoID); err != nil {
+		return err
+	}
+
+	return v.Err()
+}
+
+// validatePatch checks only the members present in the patch. Null is
+// accepted for rg alone; the other fields are required.
+func (s *colaboradorService) validatePatch(ctx context.Context, id uuid.UUID, req *dto.PatchColaboradorRequest) error {
+	v := apperror.NewViolations()
+
+	if req.Nome.Set && (req.Nome.Null || strings.TrimSpace(req.Nome.Value) == "") {
+		v.Add(apperror.ErrNomeRequired)
+	}
+
+	if req.CPF.Set {
+		if req.CPF.Null {
+			v.Add(apperror.ErrCPFRequired)
+		} else if err := checkCPF(ctx, s.repo, s.logger, v, req.CPF.Value, &id); err != nil {
+			return err
+		}
+	}
+
+	if req.RG.Set && !req.RG.Null {
+		if err := checkRG(ctx, s.repo, s.logger, v, req.RG.Value, &id); err != nil {
+			return err
+		}
+	}
+
+	if req.DepartamentoID.Set {
+		if req.DepartamentoID.Null {
+			v.Add(apperror.ErrDepartamentoRequired)
+		} else {
+			if err := s.checkDepartamento(ctx, v, req.DepartamentoID.Value); err != nil {
+				return err
+			}
+			if err := checkCanLeave(ctx, s.deptRepo, s.logger, v, id, req.DepartamentoID.Value); err != nil {
+				return err
+			}
+		}
+	}
+
+	return v.Err()
+}
+
+// validateSuccessors returns who takes over each managed departamento. A
+// colaborador works in a single departamento, so managing several takes a
+// successor per departamento, and each must be another active colaborador
+// already working in the departamento they take over. Departamentos left
+// without a successor, or handed to someone from elsewhere, are listed in
+// the error details.
+func (s *colaboradorService) validateSuccessors(ctx context.Context, repo repository.ColaboradorRepository, id uuid.UUID, opts *dto.DeleteColaboradorOptions, managed []model.Departamento) (map[uuid.UUID]uuid.UUID, error) {
+	for departamentoID := range opts.Successors {
+		if !slices.ContainsFunc(managed, func(d model.Departamento) bool { return d.ID == departamentoID }) {
+			s.logger.Warn("Successor given for unmanaged department", zap.String("departamento_id", departamentoID.String()))
+			return nil, apperror.ErrSuccessorNotManaged
+		}
+	}
+
+	successors := make(map[uuid.UUID]uuid.UUID, len(managed))
+	found := make(map[uuid.UUID]*model.Colaborador)
+	var missing, outside []model.Departamento
+	for _, d := range managed {
+		successorID := opts.SuccessorFor(d.ID)
+		if successorID == nil {
+			missing = append(missing, d)
+			continue
+		}
+		if *successorID == id {
+			s.logger.Warn("Successor is the colaborador being deleted", zap.String("id", id.String()))
+			return nil, apperror.ErrSuccessorInvalid
+		}
+
+		successor, ok := found[*successorID]
+		if !ok {
+			var err error
+			successor, err = repo.GetByID(ctx, *successorID)
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					s.logger.Warn("Successor not found", zap.String("successor_gerente_id", successorID.String()))
+					return nil, apperror.ErrSuccessorNotFound
+				}
+				s.logger.Error("Failed to get successor", zap.Error(err))
+				return nil, apperror.Internal("Erro ao buscar sucessor", err)
+			}
+			found[*successorID] = successor
+		}
+
+		if successor.DepartamentoID != d.ID {
+			s.logger.Warn("Successor not in managed department",
+				zap.String("successor_gerente_id", successorID.String()),
+				zap.String("departamento_id", d.ID.String()))
+			outside = append(outside, d)
+			continue
+		}
+		successors[d.ID] = *successorID
+	}
+
+	if len(missing) > 0 {
+		return nil, s.gerenteError(id, missing)
+	}
+	if len(outside) > 0 {
+		return nil, apperror.ErrSuccessorOutsideDepartamento.WithDetails(colaboradorDependents(outside))
+	}
+	return successors, nil
+}
+
+// checkCPF and checkRG are shared by every write path that creates or edits
+// a colaborador, including departamento bootstrap.
+func checkCPF(ctx context.Context, repo repository.ColaboradorRepository, logger *zap.Logger, v *apperror.Violations, cpf string, excludeID *uuid.UUID) error {
+	if !validator.ValidateCPF(cpf) {
+		logger.Warn("Invalid CPF provided", zap.String("cpf", cpf))
+		v.Add(apperror.ErrCPFInvalid)
+		return nil
+	}
+
+	exists, err := repo.ExistsByCPF(ctx, cpf, excludeID)
+	if err != nil {
+		logger.Error("Failed to check CPF existence", zap.Error(err))
+		return apperror.Internal("Erro ao verificar CPF", err)
+	}
+	if exists {
+		logger.Warn("CPF already exists", zap.String("cpf", cpf))
+		v.Add(apperror.ErrCPFTaken)
+	}
+	return nil
+}
+
+func checkRG(ctx context.Context, repo repository.ColaboradorRepository, logger *zap.Logger, v *apperror.Violations, rg string, excludeID *uuid.UUID) error {
+	if !validator.ValidateRG(rg) {
+		logger.Warn("Invalid RG provided", zap.String("rg", rg))
+		v.Add(apperror.ErrRGInvalid)
+		return nil
+	}
+
+	exists, err := repo.ExistsByRG(ctx, rg, excludeID)
+	if err != nil {
+		logger.Error("Failed to check RG existence", zap.Error(err))
+		return apperror.Internal("Erro ao verificar RG", err)
+	}
+	if exists {
+		logger.Warn("RG already exists", zap.String("rg", rg))
+		v.Add(apperror.ErrRGTaken)
+	}
+	return nil
+}
+
+func (s *colaboradorService) checkDepartamento(ctx context.Context, v *apperror.Violations, departamentoID uuid.UUID) error {
+	_, err := s.deptRepo.GetByID(ctx, departamentoID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			s.logger.Warn("Department not found", zap.String("departamento_id", departamentoID.String()))
+			v.Add(apperror.ErrDepartamentoNotFound)
+			return nil
+		}
+		s.logger.Error("Failed to get department", zap.Error(err))
+		return apperror.Internal("Erro ao buscar departamento", err)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"takehome-go/internal/apperror"
+	"takehome-go/internal/dto"
+	"takehome-go/internal/model"
+	"takehome-go/internal/repository"
+)
+
+// colaboradoresByID serves GetByID from memory; any other method panics.
+type colaboradoresByID struct {
+	repository.ColaboradorRepository
+	byID map[uuid.UUID]*model.Colaborador
+}
+
+func (r colaboradoresByID) GetByID(_ context.Context, id uuid.UUID) (*model.Colaborador, error) {
+	if c, ok := r.byID[id]; ok {
+		return c, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func TestValidateSuccessors(t *testing.T) {
+	gerente := uuid.New()
+	ti := model.Departamento{ID: uuid.New(), Nome: "TI"}
+	rh := model.Departamento{ID: uuid.New(), Nome: "RH"}
+	fromTI := &model.Colaborador{ID: uuid.New(), DepartamentoID: ti.ID}
+	fromRH := &model.Colaborador{ID: uuid.New(), DepartamentoID: rh.ID}
+	repo := colaboradoresByID{byID: map[uuid.UUID]*model.Colaborador{fromTI.ID: fromTI, fromRH.ID: fromRH}}
+	svc := &colaboradorService{logger: zap.NewNop()}
+
+	tests := []struct {
+		name        string
+		opts        dto.DeleteColaboradorOptions
+		managed     []model.Departamento
+		want        map[uuid.UUID]uuid.UUID
+		wantErr     error
+		wantPending []uuid.UUID
+	}{
+		{
+			name:    "single departamento with successor_gerente_id",
+			opts:    dto.DeleteColaboradorOptions{SuccessorGerenteID: &fromTI.ID},
+			managed: []model.Departamento{ti},
+			want:    map[uuid.UUID]uuid.UUID{ti.ID: fromTI.ID},
+		},
+		{
+			name:    "one successor per departamento",
+			opts:    dto.DeleteColaboradorOptions{Successors: map[uuid.UUID]uuid.UUID{ti.ID: fromTI.ID, rh.ID: fromRH.ID}},
+			managed: []model.Departamento{ti, rh},
+			want:    map[uuid.UUID]uuid.UUID{ti.ID: fromTI.ID, rh.ID: fromRH.ID},
+		},
+		{
+			name: "successor_gerente_id covers the rest",
+			opts: dto.DeleteColaboradorOptions{
+				SuccessorGerenteID: &fromTI.ID,
+				Successors:         map[uuid.UUID]uuid.UUID{rh.ID: fromRH.ID},
+			},
+			managed: []model.Departamento{ti, rh},
+			want:    map[uuid.UUID]uuid.UUID{ti.ID: fromTI.ID, rh.ID: fromRH.ID},
+		},
+		{
+			name:        "departamento without successor",
+			opts:        dto.DeleteColaboradorOptions{Successors: map[uuid.UUID]uuid.UUID{ti.ID: fromTI.ID}},
+			managed:     []model.Departamento{ti, rh},
+			wantErr:     apperror.ErrColaboradorIsGerente,
+			wantPending: []uuid.UUID{rh.ID},
+		},
+		{
+			name:        "one successor for two departamentos",
+			opts:        dto.DeleteColaboradorOptions{SuccessorGerenteID: &fromTI.ID},
+			managed:     []model.Departamento{ti, rh},
+			wantErr:     apperror.ErrSuccessorOutsideDepartamento,
+			wantPending: []uuid.UUID{rh.ID},
+		},
+		{
+			name:    "successor for an unmanaged departamento",
+			opts:    dto.DeleteColaboradorOptions{Successors: map[uuid.UUID]uuid.UUID{rh.ID: fromRH.ID}},
+			managed: []model.Departamento{ti},
+			wantErr: apperror.ErrSuccessorNotManaged,
+		},
+		{
+			name:    "successor is the gerente",
+			opts:    dto.DeleteColaboradorOptions{SuccessorGerenteID: &gerente},
+			managed: []model.Departamento{ti},
+			wantErr: apperror.ErrSuccessorInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := svc.validateSuccessors(context.Background(), repo, gerente, &tt.opts, tt.managed)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				if tt.wantPending != nil {
+					var appErr *apperror.Error
+					errors.As(err, &appErr)
+					dependents := appErr.Details.(dto.ColaboradorDependents)
+					if len(dependents.Departamentos) != len(tt.wantPending) || dependents.Departamentos[0].ID != tt.wantPending[0] {
+						t.Fatalf("details = %+v, want %v", dependents.Departamentos, tt.wantPending)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("successors = %v, want %v", got, tt.want)
+			}
+			for departamentoID, successorID := range tt.want {
+				if got[departamentoID] != successorID {
+					t.Fatalf("successors = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
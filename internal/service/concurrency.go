@@ -0,0 +1,31 @@
+package service
+
+import (
+	"errors"
+
+	"takehome-go/internal/apperror"
+	"takehome-go/internal/repository"
+)
+
+// checkVersion rejects a write up front when the version the client based
+// its change on (If-Match) is not the one currently stored. A nil expected
+// version means the client sent no precondition.
+func checkVersion(expected *int64, current int64) error {
+	if expected != nil && *expected != current {
+		return apperror.ErrPreconditionFailed
+	}
+	return nil
+}
+
+// staleVersionError maps a lost update race to 412 when the client asked for
+// a precondition and to 409 otherwise. Any other error is returned as nil so
+// callers can fall through to their own handling.
+func staleVersionError(err error, expected *int64) error {
+	if !errors.Is(err, repository.ErrStaleVersion) {
+		return nil
+	}
+	if expected != nil {
+		return apperror.ErrPreconditionFailed
+	}
+	return apperror.ErrConcurrentUpdate
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"takehome-go/internal/apperror"
+	"takehome-go/internal/database"
+	"takehome-go/internal/dto"
+	"takehome-go/internal/model"
+	"takehome-go/internal/repository"
+)
+
+// testDSNEnv points at a Postgres migrated with Flyway. Tests that need it
+// run inside a transaction that is rolled back, and are skipped without it.
+const testDSNEnv = "TEST_DATABASE_DSN"
+
+func TestRestoreAfterDelete(t *testing.T) {
+	ctx := context.Background()
+	tx := beginTestTx(t)
+	colabRepo := repository.NewColaboradorRepository(tx)
+	svc := NewDepartamentoService(
+		repository.NewDepartamentoRepository(tx),
+		colabRepo,
+		repository.NewUnitOfWork(tx),
+		database.NewNoopCache(),
+		CacheOptions{},
+		zap.NewNop(),
+	)
+	rng := rand.New(rand.NewPCG(5, 6))
+
+	bootstrap := func(t *testing.T, nome string) *model.Departamento {
+		t.Helper()
+		departamento, err := svc.Bootstrap(ctx, &dto.BootstrapDepartamentoRequest{
+			Nome:    nome,
+			Gerente: dto.BootstrapGerenteRequest{Nome: "Gerente " + nome, CPF: randomCPF(rng)},
+		})
+		if err != nil {
+			t.Fatalf("bootstrap %s: %v", nome, err)
+		}
+		return departamento
+	}
+
+	assertGerenteIn := func(t *testing.T, departamento *model.Departamento, gerenteID uuid.UUID) {
+		t.Helper()
+		if departamento.GerenteID != gerenteID {
+			t.Fatalf("gerente = %s, want %s", departamento.GerenteID, gerenteID)
+		}
+		gerente, err := colabRepo.GetByID(ctx, departamento.GerenteID)
+		if err != nil {
+			t.Fatalf("gerente not active after restore: %v", err)
+		}
+		if gerente.DepartamentoID != departamento.ID {
+			t.Fatalf("gerente works in %s, want %s", gerente.DepartamentoID, departamento.ID)
+		}
+	}
+
+	t.Run("cascade", func(t *testing.T) {
+		departamento := bootstrap(t, "restore-cascade")
+
+		if err := svc.Delete(ctx, departamento.ID, &dto.DeleteDepartamentoOptions{Cascade: true}); err != nil {
+			t.Fatalf("delete: %v", err)
+		}
+		restored, err := svc.Restore(ctx, departamento.ID, &dto.RestoreDepartamentoOptions{})
+		if err != nil {
+			t.Fatalf("restore: %v", err)
+		}
+		assertGerenteIn(t, restored, departamento.GerenteID)
+	})
+
+	t.Run("reassign", func(t *testing.T) {
+		departamento := bootstrap(t, "restore-origem")
+		target := bootstrap(t, "restore-destino")
+
+		err := svc.Delete(ctx, departamento.ID, &dto.DeleteDepartamentoOptions{ReassignColaboradoresTo: &target.ID})
+		if err != nil {
+			t.Fatalf("delete: %v", err)
+		}
+
+		_, err = svc.Restore(ctx, departamento.ID, &dto.RestoreDepartamentoOptions{})
+		if !errors.Is(err, apperror.ErrGerenteTransferred) {
+			t.Fatalf("restore without gerente_id: got %v, want %v", err, apperror.ErrGerenteTransferred)
+		}
+
+		restored, err := svc.Restore(ctx, departamento.ID, &dto.RestoreDepartamentoOptions{GerenteID: &departamento.GerenteID})
+		if err != nil {
+			t.Fatalf("restore: %v", err)
+		}
+		assertGerenteIn(t, restored, departamento.GerenteID)
+	})
+}
+
+func beginTestTx(tb testing.TB) *gorm.DB {
+	tb.Helper()
+	dsn := os.Getenv(testDSNEnv)
+	if dsn == "" {
+		tb.Skipf("%s not set", testDSNEnv)
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: gormlogger.Default.LogMode(gormlogger.Silent)})
+	if err != nil {
+		tb.Fatalf("connect: %v", err)
+	}
+	tx := db.Begin()
+	if tx.Error != nil {
+		tb.Fatalf("begin: %v", tx.Error)
+	}
+	tb.Cleanup(func() { tx.Rollback() })
+	return tx
+}
+
+// randomCPF returns a CPF with valid check digits.
+func randomCPF(rng *rand.Rand) string {
+	digits := make([]int, 11)
+	for i := 0; i < 9; i++ {
+		digits[i] = rng.IntN(10)
+	}
+	for n := 9; n < 11; n++ {
+		sum := 0
+		for i := 0; i < n; i++ {
+			sum += digits[i] * (n + 1 - i)
+		}
+		digits[n] = sum * 10 % 11 % 10
+	}
+	cpf := ""
+	for _, d := range digits {
+		cpf += fmt.Sprint(d)
+	}
+	return cpf
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 
+	"takehome-go/internal/apperror"
 	"takehome-go/internal/database"
 	"takehome-go/internal/dto"
 	"takehome-go/internal/model"
@@ -18,9 +20,19 @@ import (
 
 type DepartamentoService interface {
 	Create(ctx context.Context, req *dto.CreateDepartamentoRequest) (*model.Departamento, error)
+	Bootstrap(ctx context.Context, req *dto.BootstrapDepartamentoRequest) (*model.Departamento, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*dto.DepartamentoResponse, error)
-	Update(ctx context.Context, id uuid.UUID, req *dto.UpdateDepartamentoRequest) (*model.Departamento, error)
-	Delete(ctx context.Context, id uuid.UUID) error
+	GetAncestors(ctx context.Context, id uuid.UUID) ([]dto.DepartamentoAncestral, error)
+	GetOrganograma(ctx context.Context, opts *dto.OrganogramaOptions) (*dto.OrganogramaResponse, error)
+	ExportOrganograma(ctx context.Context, format string) ([]byte, error)
+	Update(ctx context.Context, id uuid.UUID, req *dto.UpdateDepartamentoRequest, expectedVersion *int64) (*model.Departamento, error)
+	Patch(ctx context.Context, id uuid.UUID, req *dto.PatchDepartamentoRequest, expectedVersion *int64) (*model.Departamento, error)
+	Delete(ctx context.Context, id uuid.UUID, opts *dto.DeleteDepartamentoOptions) error
+	Restore(ctx context.Context, id uuid.UUID, opts *dto.RestoreDepartamentoOptions) (*model.Departamento, error)
+	ChangeGerente(ctx context.Context, id uuid.UUID, req *dto.ChangeGerenteRequest, expectedVersion *int64) (*model.Departamento, error)
+	Move(ctx context.Context, id uuid.UUID, req *dto.MoveDepartamentoRequest, expectedVersion *int64) (*dto.MoveDepartamentoResponse, error)
+	Merge(ctx context.Context, id uuid.UUID, req *dto.MergeDepartamentoRequest, expectedVersion *int64) (*dto.MergeDepartamentoResponse, error)
+	Split(ctx context.Context, id uuid.UUID, req *dto.SplitDepartamentoRequest) (*dto.SplitDepartamentoResponse, error)
 	List(ctx context.Context, filters map[string]interface{}, page, pageSize int) (*dto.ListDepartamentosResponse, error)
 	GetColaboradoresByGerente(ctx context.Context, gerenteID uuid.UUID) ([]model.Colaborador, error)
 }
@@ -28,20 +40,30 @@ type DepartamentoService interface {
 type departamentoService struct {
 	repo      repository.DepartamentoRepository
 	colabRepo repository.ColaboradorRepository
+	uow       repository.UnitOfWork
 	cache     database.Cache
+	byID      *cachedReader[dto.DepartamentoResponse]
+	lists     *listCache[dto.ListDepartamentosResponse]
+	byGerente *listCache[[]model.Colaborador]
 	logger    *zap.Logger
 }
 
 func NewDepartamentoService(
 	repo repository.DepartamentoRepository,
 	colabRepo repository.ColaboradorRepository,
+	uow repository.UnitOfWork,
 	cache database.Cache,
+	cacheOpts CacheOptions,
 	logger *zap.Logger,
 ) DepartamentoService {
 	return &departamentoService{
 		repo:      repo,
 		colabRepo: colabRepo,
+		uow:       uow,
 		cache:     cache,
+		byID:      newCachedReader[dto.DepartamentoResponse]("departamento", cache, cacheOpts, logger),
+		lists:     newListCache[dto.ListDepartamentosResponse]("departamentos", cacheOpts.DepartamentosListTTL, cache, cacheOpts, logger),
+		byGerente: newListCache[[]model.Colaborador]("gerente_colaboradores", cacheOpts.GerenteColaboradoresTTL, cache, cacheOpts, logger),
 		logger:    logger,
 	}
 }
@@ -49,47 +71,103 @@ func NewDepartamentoService(
 func (s *departamentoService) Create(ctx context.Context, req *dto.CreateDepartamentoRequest) (*model.Departamento, error) {
 	s.logger.Info("Creating departamento", zap.String("nome", req.Nome))
 
-	gerente, err := s.colabRepo.GetByID(ctx, req.GerenteID)
+	gerente, err := s.validateCreate(ctx, req)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			s.logger.Warn("Gerente not found", zap.String("gerente_id", req.GerenteID.String()))
-			return nil, errors.New("Gerente não encontrado")
-		}
-		s.logger.Error("Failed to get gerente", zap.Error(err))
-		return nil, errors.New("Erro ao buscar gerente")
+		return nil, err
 	}
 
-	if req.DepartamentoSuperiorID != nil {
-		_, err := s.repo.GetByID(ctx, *req.DepartamentoSuperiorID)
+	now := time.Now()
+	departamento := &model.Departamento{
+		Nome:                   req.Nome,
+		GerenteID:              req.GerenteID,
+		DepartamentoSuperiorID: req.DepartamentoSuperiorID,
+		GerenteDesde:           &now,
+	}
+
+	err = s.uow.WithTx(ctx, func(tx repository.Repos) error {
+		existing, err := existingGerenteViolations(ctx, tx.Departamentos, s.logger, nil, []uuid.UUID{gerente.ID})
 		if err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				s.logger.Warn("Superior department not found", zap.String("departamento_superior_id", req.DepartamentoSuperiorID.String()))
-				return nil, errors.New("Departamento superior não encontrado")
+			return err
+		}
+
+		if err := tx.Departamentos.Create(ctx, departamento); err != nil {
+			s.logger.Error("Failed to create departamento", zap.Error(err))
+			return apperror.Internal("Erro ao criar departamento", err)
+		}
+
+		if gerente.DepartamentoID != departamento.ID {
+			gerente.DepartamentoID = departamento.ID
+			if err := tx.Colaboradores.Update(ctx, gerente); err != nil {
+				if staleErr := staleVersionError(err, nil); staleErr != nil {
+					s.logger.Warn("Gerente changed concurrently", zap.String("gerente_id", gerente.ID.String()))
+					return staleErr
+				}
+				s.logger.Error("Failed to update gerente department", zap.Error(err))
+				return apperror.Internal("Erro ao atualizar departamento do gerente", err)
 			}
-			s.logger.Error("Failed to get superior department", zap.Error(err))
-			return nil, errors.New("Erro ao buscar departamento superior")
 		}
+		return enforceGerenteInvariant(ctx, tx.Departamentos, s.logger, existing, []uuid.UUID{departamento.ID}, []uuid.UUID{gerente.ID})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidate(ctx, append(superiorTags(departamento.DepartamentoSuperiorID), colaboradorTag(gerente.ID))...)
+
+	s.logger.Info("Departamento created successfully", zap.String("id", departamento.ID.String()))
+	return departamento, nil
+}
+
+// Bootstrap creates a departamento together with a brand-new colaborador as
+// its gerente. Each side references the other, so the departamento is
+// inserted without gerente first and completed once the gerente exists.
+func (s *departamentoService) Bootstrap(ctx context.Context, req *dto.BootstrapDepartamentoRequest) (*model.Departamento, error) {
+	s.logger.Info("Bootstrapping departamento", zap.String("nome", req.Nome), zap.String("gerente_cpf", req.Gerente.CPF))
+
+	if err := s.validateBootstrap(ctx, req); err != nil {
+		return nil, err
 	}
 
 	departamento := &model.Departamento{
+		ID:                     uuid.Must(uuid.NewV7()),
 		Nome:                   req.Nome,
-		GerenteID:              req.GerenteID,
 		DepartamentoSuperiorID: req.DepartamentoSuperiorID,
 	}
-
-	if err := s.repo.Create(ctx, departamento); err != nil {
-		s.logger.Error("Failed to create departamento", zap.Error(err))
-		return nil, errors.New("Erro ao criar departamento")
+	gerente := &model.Colaborador{
+		Nome:           req.Gerente.Nome,
+		CPF:            req.Gerente.CPF,
+		RG:             req.Gerente.RG,
+		DepartamentoID: departamento.ID,
 	}
 
-	if gerente.DepartamentoID != departamento.ID {
-		gerente.DepartamentoID = departamento.ID
-		if err := s.colabRepo.Update(ctx, gerente); err != nil {
-			s.logger.Error("Failed to update gerente department", zap.Error(err))
+	err := s.uow.WithTx(ctx, func(tx repository.Repos) error {
+		if err := tx.Departamentos.CreateWithoutGerente(ctx, departamento); err != nil {
+			s.logger.Error("Failed to create departamento", zap.Error(err))
+			return apperror.Internal("Erro ao criar departamento", err)
 		}
+
+		if err := tx.Colaboradores.Create(ctx, gerente); err != nil {
+			s.logger.Error("Failed to create gerente", zap.Error(err))
+			return apperror.Internal("Erro ao criar gerente", err)
+		}
+
+		now := time.Now()
+		departamento.GerenteID = gerente.ID
+		departamento.GerenteDesde = &now
+		if err := tx.Departamentos.Update(ctx, departamento); err != nil {
+			s.logger.Error("Failed to assign gerente", zap.Error(err))
+			return apperror.Internal("Erro ao atribuir gerente", err)
+		}
+		return enforceGerenteInvariant(ctx, tx.Departamentos, s.logger, nil, []uuid.UUID{departamento.ID}, nil)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	s.logger.Info("Departamento created successfully", zap.String("id", departamento.ID.String()))
+	departamento.Gerente = gerente
+	s.invalidate(ctx, superiorTags(departamento.DepartamentoSuperiorID)...)
+
+	s.logger.Info("Departamento bootstrapped successfully", zap.String("id", departamento.ID.String()), zap.String("gerente_id", gerente.ID.String()))
 	return departamento, nil
 }
 
@@ -97,20 +175,29 @@ func (s *departamentoService) GetByID(ctx context.Context, id uuid.UUID) (*dto.D
 	s.logger.Info("Getting departamento by ID", zap.String("id", id.String()))
 
 	cacheKey := fmt.Sprintf("departamento:%s", id.String())
-	var cached dto.DepartamentoResponse
-	if err := s.cache.Get(ctx, cacheKey, &cached); err == nil {
-		s.logger.Debug("Departamento found in cache", zap.String("id", id.String()))
-		return &cached, nil
+	response, err := s.byID.Get(ctx, cacheKey, func(ctx context.Context) (*dto.DepartamentoResponse, []string, bool, error) {
+		return s.loadDepartamento(ctx, id)
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	s.logger.Info("Departamento retrieved successfully", zap.String("id", id.String()))
+	return response, nil
+}
+
+// loadDepartamento builds the GetByID response from the database, tagged
+// with every departamento and gerente it shows and with its ancestors,
+// whose moves change its depth.
+func (s *departamentoService) loadDepartamento(ctx context.Context, id uuid.UUID) (*dto.DepartamentoResponse, []string, bool, error) {
 	departamento, err := s.repo.GetByIDWithHierarchy(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			s.logger.Warn("Departamento not found", zap.String("id", id.String()))
-			return nil, errors.New("Departamento não encontrado")
+			return nil, nil, false, apperror.ErrDepartamentoNotFound
 		}
 		s.logger.Error("Failed to get departamento", zap.Error(err))
-		return nil, errors.New("Erro ao buscar departamento")
+		return nil, nil, false, apperror.Internal("Erro ao buscar departamento", err)
 	}
 
 	response := &dto.DepartamentoResponse{
@@ -118,174 +205,872 @@ func (s *departamentoService) GetByID(ctx context.Context, id uuid.UUID) (*dto.D
 		Nome:                   departamento.Nome,
 		Gerente:                departamento.Gerente,
 		DepartamentoSuperiorID: departamento.DepartamentoSuperiorID,
+		GerenteDesde:           departamento.GerenteDesde,
+		Depth:                  *departamento.Depth,
 		Subdepartamentos:       departamento.Subdepartamentos,
+		Version:                departamento.Version,
 		CreatedAt:              departamento.CreatedAt,
 		UpdatedAt:              departamento.UpdatedAt,
 	}
 
-	s.cache.Set(ctx, cacheKey, response, 5*time.Minute)
-	s.logger.Info("Departamento retrieved successfully", zap.String("id", id.String()))
+	ancestors, err := s.repo.GetAncestors(ctx, id)
+	if err != nil {
+		s.logger.Warn("Failed to get ancestors for cache tags", zap.Error(err))
+		return response, nil, false, nil
+	}
+	return response, departamentoTreeTags(departamento, ancestors), true, nil
+}
+
+// GetAncestors returns the breadcrumb from the root down to the departamento.
+func (s *departamentoService) GetAncestors(ctx context.Context, id uuid.UUID) ([]dto.DepartamentoAncestral, error) {
+	s.logger.Info("Getting departamento ancestors", zap.String("id", id.String()))
+
+	ancestors, err := s.repo.GetAncestors(ctx, id)
+	if err != nil {
+		s.logger.Error("Failed to get ancestors", zap.Error(err))
+		return nil, apperror.Internal("Erro ao buscar hierarquia do departamento", err)
+	}
+	if len(ancestors) == 0 {
+		s.logger.Warn("Departamento not found", zap.String("id", id.String()))
+		return nil, apperror.ErrDepartamentoNotFound
+	}
+
+	response := make([]dto.DepartamentoAncestral, 0, len(ancestors))
+	for _, d := range ancestors {
+		response = append(response, dto.DepartamentoAncestral{
+			ID:                     d.ID,
+			Nome:                   d.Nome,
+			DepartamentoSuperiorID: d.DepartamentoSuperiorID,
+			Gerente:                d.Gerente,
+			Depth:                  *d.Depth,
+		})
+	}
 
 	return response, nil
 }
 
-func (s *departamentoService) Update(ctx context.Context, id uuid.UUID, req *dto.UpdateDepartamentoRequest) (*model.Departamento, error) {
+func (s *departamentoService) Update(ctx context.Context, id uuid.UUID, req *dto.UpdateDepartamentoRequest, expectedVersion *int64) (*model.Departamento, error) {
 	s.logger.Info("Updating departamento", zap.String("id", id.String()))
+	return s.applyPatch(ctx, id, req.ToPatch(), expectedVersion)
+}
 
+func (s *departamentoService) Patch(ctx context.Context, id uuid.UUID, req *dto.PatchDepartamentoRequest, expectedVersion *int64) (*model.Departamento, error) {
+	s.logger.Info("Patching departamento", zap.String("id", id.String()))
+	return s.applyPatch(ctx, id, req, expectedVersion)
+}
+
+// applyPatch is shared by PUT and PATCH; PUT requests are converted to a
+// patch first so both go through the same validations.
+func (s *departamentoService) applyPatch(ctx context.Context, id uuid.UUID, req *dto.PatchDepartamentoRequest, expectedVersion *int64) (*model.Departamento, error) {
 	departamento, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			s.logger.Warn("Departamento not found", zap.String("id", id.String()))
-			return nil, errors.New("Departamento não encontrado")
+			return nil, apperror.ErrDepartamentoNotFound
 		}
 		s.logger.Error("Failed to get departamento", zap.Error(err))
-		return nil, errors.New("Erro ao buscar departamento")
+		return nil, apperror.Internal("Erro ao buscar departamento", err)
+	}
+
+	if err := checkVersion(expectedVersion, departamento.Version); err != nil {
+		s.logger.Warn("Departamento version mismatch", zap.String("id", id.String()), zap.Int64("version", departamento.Version))
+		return nil, err
+	}
+
+	if err := s.validatePatch(ctx, id, req); err != nil {
+		return nil, err
 	}
 
-	if req.Nome != "" {
-		departamento.Nome = req.Nome
+	if req.Nome.Set {
+		departamento.Nome = req.Nome.Value
+	}
+	if req.GerenteID.Set && req.GerenteID.Value != departamento.GerenteID {
+		now := time.Now()
+		departamento.GerenteID = req.GerenteID.Value
+		departamento.GerenteDesde = &now
+	}
+	if req.DepartamentoSuperiorID.Set {
+		if req.DepartamentoSuperiorID.Null {
+			departamento.DepartamentoSuperiorID = nil
+		} else {
+			superiorID := req.DepartamentoSuperiorID.Value
+			departamento.DepartamentoSuperiorID = &superiorID
+		}
 	}
 
-	if req.GerenteID != nil {
-		gerente, err := s.colabRepo.GetByID(ctx, *req.GerenteID)
+	err = s.uow.WithTx(ctx, func(tx repository.Repos) error {
+		existing, err := existingGerenteViolations(ctx, tx.Departamentos, s.logger, []uuid.UUID{id}, nil)
 		if err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				s.logger.Warn("Gerente not found", zap.String("gerente_id", req.GerenteID.String()))
-				return nil, errors.New("Gerente não encontrado")
+			return err
+		}
+
+		if err := tx.Departamentos.Update(ctx, departamento); err != nil {
+			if staleErr := staleVersionError(err, expectedVersion); staleErr != nil {
+				s.logger.Warn("Departamento changed concurrently", zap.String("id", id.String()))
+				return staleErr
 			}
-			s.logger.Error("Failed to get gerente", zap.Error(err))
-			return nil, errors.New("Erro ao buscar gerente")
+			if errors.Is(err, apperror.ErrHierarchyCycle) {
+				s.logger.Warn("Cycle rejected by database", zap.String("id", id.String()))
+				return err
+			}
+			s.logger.Error("Failed to update departamento", zap.Error(err))
+			return apperror.Internal("Erro ao atualizar departamento", err)
 		}
+		return enforceGerenteInvariant(ctx, tx.Departamentos, s.logger, existing, []uuid.UUID{id}, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		if gerente.DepartamentoID != id {
-			s.logger.Warn("Gerente not in same department", zap.String("gerente_id", req.GerenteID.String()))
-			return nil, errors.New("Gerente deve pertencer ao mesmo departamento")
+	// Former ancestors and gerentes are covered by the departamento's own tag.
+	s.invalidate(ctx, append(superiorTags(departamento.DepartamentoSuperiorID), departamentoTag(id))...)
+
+	s.logger.Info("Departamento updated successfully", zap.String("id", id.String()))
+	return departamento, nil
+}
+
+// ChangeGerente runs a manager succession in one transaction: the new
+// gerente is moved into the departamento when they work elsewhere, takes
+// over from the effective date, and the outgoing gerente is optionally
+// relocated.
+func (s *departamentoService) ChangeGerente(ctx context.Context, id uuid.UUID, req *dto.ChangeGerenteRequest, expectedVersion *int64) (*model.Departamento, error) {
+	s.logger.Info("Changing gerente", zap.String("id", id.String()), zap.String("gerente_id", req.GerenteID.String()))
+
+	departamento, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			s.logger.Warn("Departamento not found", zap.String("id", id.String()))
+			return nil, apperror.ErrDepartamentoNotFound
 		}
+		s.logger.Error("Failed to get departamento", zap.Error(err))
+		return nil, apperror.Internal("Erro ao buscar departamento", err)
+	}
+
+	if err := checkVersion(expectedVersion, departamento.Version); err != nil {
+		s.logger.Warn("Departamento version mismatch", zap.String("id", id.String()), zap.Int64("version", departamento.Version))
+		return nil, err
+	}
+
+	gerente, err := s.validateChangeGerente(ctx, departamento, req)
+	if err != nil {
+		return nil, err
+	}
 
-		departamento.GerenteID = *req.GerenteID
+	effectiveDate := time.Now()
+	if req.EffectiveDate != nil {
+		effectiveDate = *req.EffectiveDate
 	}
+	outgoingID := departamento.GerenteID
+
+	err = s.uow.WithTx(ctx, func(tx repository.Repos) error {
+		existing, err := existingGerenteViolations(ctx, tx.Departamentos, s.logger, []uuid.UUID{id}, []uuid.UUID{gerente.ID, outgoingID})
+		if err != nil {
+			return err
+		}
 
-	if req.DepartamentoSuperiorID != nil {
-		if *req.DepartamentoSuperiorID != uuid.Nil {
-			_, err := s.repo.GetByID(ctx, *req.DepartamentoSuperiorID)
+		if gerente.DepartamentoID != id {
+			gerente.DepartamentoID = id
+			if err := tx.Colaboradores.Update(ctx, gerente); err != nil {
+				if staleErr := staleVersionError(err, nil); staleErr != nil {
+					s.logger.Warn("Gerente changed concurrently", zap.String("gerente_id", gerente.ID.String()))
+					return staleErr
+				}
+				s.logger.Error("Failed to move gerente", zap.Error(err))
+				return apperror.Internal("Erro ao transferir gerente", err)
+			}
+		}
+
+		departamento.GerenteID = gerente.ID
+		departamento.GerenteDesde = &effectiveDate
+		if err := tx.Departamentos.Update(ctx, departamento); err != nil {
+			if staleErr := staleVersionError(err, expectedVersion); staleErr != nil {
+				s.logger.Warn("Departamento changed concurrently", zap.String("id", id.String()))
+				return staleErr
+			}
+			s.logger.Error("Failed to assign gerente", zap.Error(err))
+			return apperror.Internal("Erro ao atribuir gerente", err)
+		}
+
+		if req.RelocateOutgoingTo != nil {
+			outgoing, err := tx.Colaboradores.GetByID(ctx, outgoingID)
 			if err != nil {
-				if errors.Is(err, gorm.ErrRecordNotFound) {
-					s.logger.Warn("Superior department not found", zap.String("departamento_superior_id", req.DepartamentoSuperiorID.String()))
-					return nil, errors.New("Departamento superior não encontrado")
+				s.logger.Error("Failed to get outgoing gerente", zap.Error(err))
+				return apperror.Internal("Erro ao buscar gerente anterior", err)
+			}
+			outgoing.DepartamentoID = *req.RelocateOutgoingTo
+			if err := tx.Colaboradores.Update(ctx, outgoing); err != nil {
+				if staleErr := staleVersionError(err, nil); staleErr != nil {
+					s.logger.Warn("Outgoing gerente changed concurrently", zap.String("gerente_id", outgoingID.String()))
+					return staleErr
 				}
-				s.logger.Error("Failed to get superior department", zap.Error(err))
-				return nil, errors.New("Erro ao buscar departamento superior")
+				s.logger.Error("Failed to relocate outgoing gerente", zap.Error(err))
+				return apperror.Internal("Erro ao realocar gerente anterior", err)
 			}
+		}
+		return enforceGerenteInvariant(ctx, tx.Departamentos, s.logger, existing, []uuid.UUID{id}, []uuid.UUID{gerente.ID, outgoingID})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	departamento.Gerente = gerente
+	s.invalidate(ctx, departamentoTag(id), colaboradorTag(gerente.ID), colaboradorTag(outgoingID))
+
+	s.logger.Info("Gerente changed successfully",
+		zap.String("id", id.String()),
+		zap.String("gerente_id", gerente.ID.String()),
+		zap.String("gerente_anterior_id", outgoingID.String()))
+	return departamento, nil
+}
+
+// Move places a departamento and its whole subtree under a new superior.
+// The cycle check is repeated inside the transaction so a concurrent move
+// cannot slip a loop in between validation and the write.
+func (s *departamentoService) Move(ctx context.Context, id uuid.UUID, req *dto.MoveDepartamentoRequest, expectedVersion *int64) (*dto.MoveDepartamentoResponse, error) {
+	s.logger.Info("Moving departamento", zap.String("id", id.String()))
 
-			hasCycle, err := s.repo.HasCycle(ctx, id, *req.DepartamentoSuperiorID)
+	departamento, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			s.logger.Warn("Departamento not found", zap.String("id", id.String()))
+			return nil, apperror.ErrDepartamentoNotFound
+		}
+		s.logger.Error("Failed to get departamento", zap.Error(err))
+		return nil, apperror.Internal("Erro ao buscar departamento", err)
+	}
+
+	if err := checkVersion(expectedVersion, departamento.Version); err != nil {
+		s.logger.Warn("Departamento version mismatch", zap.String("id", id.String()), zap.Int64("version", departamento.Version))
+		return nil, err
+	}
+
+	if err := s.validateMove(ctx, departamento, req); err != nil {
+		return nil, err
+	}
+
+	before, err := s.repo.GetAncestors(ctx, id)
+	if err != nil {
+		s.logger.Error("Failed to get ancestors", zap.Error(err))
+		return nil, apperror.Internal("Erro ao buscar hierarquia do departamento", err)
+	}
+
+	departamento.DepartamentoSuperiorID = req.DepartamentoSuperiorID
+
+	err = s.uow.WithTx(ctx, func(tx repository.Repos) error {
+		if superiorID := req.DepartamentoSuperiorID; superiorID != nil {
+			hasCycle, err := tx.Departamentos.HasCycle(ctx, id, *superiorID)
 			if err != nil {
 				s.logger.Error("Failed to check cycle", zap.Error(err))
-				return nil, errors.New("Erro ao verificar ciclo na hierarquia")
+				return apperror.Internal("Erro ao verificar ciclo na hierarquia", err)
 			}
 			if hasCycle {
-				s.logger.Warn("Cycle detected in hierarchy", zap.String("departamento_superior_id", req.DepartamentoSuperiorID.String()))
-				return nil, errors.New("Operação criaria um ciclo na hierarquia de departamentos")
+				s.logger.Warn("Cycle detected in hierarchy", zap.String("departamento_superior_id", superiorID.String()))
+				return apperror.ErrHierarchyCycle
 			}
 		}
-		departamento.DepartamentoSuperiorID = req.DepartamentoSuperiorID
+
+		if err := tx.Departamentos.Update(ctx, departamento); err != nil {
+			if staleErr := staleVersionError(err, expectedVersion); staleErr != nil {
+				s.logger.Warn("Departamento changed concurrently", zap.String("id", id.String()))
+				return staleErr
+			}
+			if errors.Is(err, apperror.ErrHierarchyCycle) {
+				s.logger.Warn("Cycle rejected by database", zap.String("id", id.String()))
+				return err
+			}
+			s.logger.Error("Failed to move departamento", zap.Error(err))
+			return apperror.Internal("Erro ao mover departamento", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	if err := s.repo.Update(ctx, departamento); err != nil {
-		s.logger.Error("Failed to update departamento", zap.Error(err))
-		return nil, errors.New("Erro ao atualizar departamento")
+	after, err := s.repo.GetAncestors(ctx, id)
+	if err != nil {
+		s.logger.Error("Failed to get ancestors", zap.Error(err))
+		return nil, apperror.Internal("Erro ao buscar hierarquia do departamento", err)
 	}
 
-	cacheKey := fmt.Sprintf("departamento:%s", id.String())
-	s.cache.Delete(ctx, cacheKey)
+	// Old ancestors and the subtree carry the departamento's tag; the new
+	// ancestors carry the new superior's.
+	s.invalidate(ctx, append(superiorTags(req.DepartamentoSuperiorID), departamentoTag(id))...)
 
-	s.logger.Info("Departamento updated successfully", zap.String("id", id.String()))
-	return departamento, nil
+	s.logger.Info("Departamento moved successfully", zap.String("id", id.String()))
+	return &dto.MoveDepartamentoResponse{
+		Departamento:    departamento,
+		CaminhoAnterior: departamentoPath(before),
+		CaminhoAtual:    departamentoPath(after),
+	}, nil
 }
 
-func (s *departamentoService) Delete(ctx context.Context, id uuid.UUID) error {
-	s.logger.Info("Deleting departamento", zap.String("id", id.String()))
+// Merge folds the departamento id into req.TargetID in one transaction: its
+// colaboradores are reassigned and its children reparented to the target,
+// the chosen gerente runs the target, and the source is archived (soft
+// deleted) or deleted for good. expectedVersion applies to the source.
+func (s *departamentoService) Merge(ctx context.Context, id uuid.UUID, req *dto.MergeDepartamentoRequest, expectedVersion *int64) (*dto.MergeDepartamentoResponse, error) {
+	s.logger.Info("Merging departamento", zap.String("id", id.String()), zap.String("target_id", req.TargetID.String()))
 
-	_, err := s.repo.GetByID(ctx, id)
+	source, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			s.logger.Warn("Departamento not found", zap.String("id", id.String()))
-			return errors.New("Departamento não encontrado")
+			return nil, apperror.ErrDepartamentoNotFound
 		}
 		s.logger.Error("Failed to get departamento", zap.Error(err))
-		return errors.New("Erro ao buscar departamento")
+		return nil, apperror.Internal("Erro ao buscar departamento", err)
 	}
 
-	if err := s.repo.Delete(ctx, id); err != nil {
-		s.logger.Error("Failed to delete departamento", zap.Error(err))
-		return errors.New("Erro ao deletar departamento")
+	if err := checkVersion(expectedVersion, source.Version); err != nil {
+		s.logger.Warn("Departamento version mismatch", zap.String("id", id.String()), zap.Int64("version", source.Version))
+		return nil, err
 	}
 
-	cacheKey := fmt.Sprintf("departamento:%s", id.String())
-	s.cache.Delete(ctx, cacheKey)
+	target, err := s.validateMerge(ctx, source, req)
+	if err != nil {
+		return nil, err
+	}
 
-	s.logger.Info("Departamento deleted successfully", zap.String("id", id.String()))
-	return nil
+	colaboradores, err := s.colabRepo.GetByDepartamentoIDs(ctx, []uuid.UUID{id})
+	if err != nil {
+		s.logger.Error("Failed to get colaboradores", zap.Error(err))
+		return nil, apperror.Internal("Erro ao buscar colaboradores", err)
+	}
+	children, err := s.repo.GetChildren(ctx, id)
+	if err != nil {
+		s.logger.Error("Failed to get subdepartamentos", zap.Error(err))
+		return nil, apperror.Internal("Erro ao buscar subdepartamentos", err)
+	}
+
+	sourceAction := req.SourceAction
+	if sourceAction == "" {
+		sourceAction = dto.SourceActionArchive
+	}
+	sourceGerente := req.SurvivingGerente == dto.SurvivingGerenteSource
+
+	err = s.uow.WithTx(ctx, func(tx repository.Repos) error {
+		existing, err := existingGerenteViolations(ctx, tx.Departamentos, s.logger, []uuid.UUID{target.ID}, []uuid.UUID{source.GerenteID})
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Colaboradores.Reassign(ctx, id, target.ID); err != nil {
+			s.logger.Error("Failed to reassign colaboradores", zap.Error(err))
+			return apperror.Internal("Erro ao transferir colaboradores", err)
+		}
+		if err := tx.Departamentos.Reparent(ctx, id, target.ID); err != nil {
+			if errors.Is(err, apperror.ErrHierarchyCycle) {
+				s.logger.Warn("Cycle rejected by database", zap.String("id", id.String()))
+				return err
+			}
+			s.logger.Error("Failed to reparent subdepartamentos", zap.Error(err))
+			return apperror.Internal("Erro ao mover subdepartamentos", err)
+		}
+
+		if sourceGerente {
+			now := time.Now()
+			target.GerenteID = source.GerenteID
+			target.GerenteDesde = &now
+			if err := tx.Departamentos.Update(ctx, target); err != nil {
+				if staleErr := staleVersionError(err, nil); staleErr != nil {
+					s.logger.Warn("Target departamento changed concurrently", zap.String("target_id", target.ID.String()))
+					return staleErr
+				}
+				s.logger.Error("Failed to assign gerente", zap.Error(err))
+				return apperror.Internal("Erro ao atribuir gerente", err)
+			}
+		}
+
+		// Removing the source at the version that was checked makes the
+		// whole merge fail if the source changed since it was read.
+		remove := tx.Departamentos.DeleteAtVersion
+		if sourceAction == dto.SourceActionDelete {
+			remove = tx.Departamentos.HardDeleteAtVersion
+		}
+		if err := remove(ctx, id, source.Version); err != nil {
+			if staleErr := staleVersionError(err, expectedVersion); staleErr != nil {
+				s.logger.Warn("Source departamento changed concurrently", zap.String("id", id.String()))
+				return staleErr
+			}
+			s.logger.Error("Failed to remove source departamento", zap.Error(err))
+			return apperror.Internal("Erro ao remover departamento de origem", err)
+		}
+
+		return enforceGerenteInvariant(ctx, tx.Departamentos, s.logger, existing, []uuid.UUID{target.ID}, []uuid.UUID{source.GerenteID})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	merged, err := s.repo.GetByID(ctx, target.ID)
+	if err != nil {
+		s.logger.Error("Failed to get departamento", zap.Error(err))
+		return nil, apperror.Internal("Erro ao buscar departamento", err)
+	}
+
+	s.invalidate(ctx, departamentoTag(id), departamentoTag(target.ID))
+
+	response := &dto.MergeDepartamentoResponse{
+		Departamento:            merged,
+		Origem:                  dto.DepartamentoRef{ID: source.ID, Nome: source.Nome},
+		AcaoOrigem:              sourceAction,
+		GerenteID:               merged.GerenteID,
+		ColaboradoresMovidos:    make([]dto.DependentSummary, 0, len(colaboradores)),
+		SubdepartamentosMovidos: departamentoPath(children),
+	}
+	for _, c := range colaboradores {
+		response.ColaboradoresMovidos = append(response.ColaboradoresMovidos, dto.DependentSummary{ID: c.ID, Nome: c.Nome})
+	}
+
+	s.logger.Info("Departamento merged successfully",
+		zap.String("id", id.String()),
+		zap.String("target_id", target.ID.String()),
+		zap.Int("colaboradores", len(colaboradores)),
+		zap.Int("subdepartamentos", len(children)))
+	return response, nil
 }
 
-func (s *departamentoService) List(ctx context.Context, filters map[string]interface{}, page, pageSize int) (*dto.ListDepartamentosResponse, error) {
-	s.logger.Info("Listing departamentos", zap.Int("page", page), zap.Int("page_size", pageSize))
+// Split creates a departamento next to (or under) id and moves the chosen
+// colaboradores and subdepartamentos into it, all in one transaction. The
+// moved subdepartamentos go through the cycle check once the new
+// departamento exists.
+func (s *departamentoService) Split(ctx context.Context, id uuid.UUID, req *dto.SplitDepartamentoRequest) (*dto.SplitDepartamentoResponse, error) {
+	s.logger.Info("Splitting departamento", zap.String("id", id.String()), zap.String("nome", req.Nome))
 
-	if page < 1 {
-		page = 1
+	source, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			s.logger.Warn("Departamento not found", zap.String("id", id.String()))
+			return nil, apperror.ErrDepartamentoNotFound
+		}
+		s.logger.Error("Failed to get departamento", zap.Error(err))
+		return nil, apperror.Internal("Erro ao buscar departamento", err)
 	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 10
+
+	create := &dto.CreateDepartamentoRequest{
+		Nome:                   req.Nome,
+		GerenteID:              req.GerenteID,
+		DepartamentoSuperiorID: source.DepartamentoSuperiorID,
+	}
+	if req.Position == dto.SplitPositionChild {
+		create.DepartamentoSuperiorID = &source.ID
 	}
 
-	departamentos, total, err := s.repo.List(ctx, filters, page, pageSize)
+	colaboradores, subdepartamentos, err := s.validateSplit(ctx, source, req, create)
 	if err != nil {
-		s.logger.Error("Failed to list departamentos", zap.Error(err))
-		return nil, errors.New("Erro ao listar departamentos")
+		return nil, err
 	}
 
-	totalPages := int(total) / pageSize
-	if int(total)%pageSize > 0 {
-		totalPages++
+	now := time.Now()
+	departamento := &model.Departamento{
+		Nome:                   create.Nome,
+		GerenteID:              create.GerenteID,
+		DepartamentoSuperiorID: create.DepartamentoSuperiorID,
+		GerenteDesde:           &now,
+	}
+	colaboradorIDs := make([]uuid.UUID, 0, len(colaboradores))
+	for _, c := range colaboradores {
+		colaboradorIDs = append(colaboradorIDs, c.ID)
 	}
+	subdepartamentoIDs := departamentoIDs(subdepartamentos)
 
-	s.logger.Info("Departamentos listed successfully", zap.Int64("total", total))
+	err = s.uow.WithTx(ctx, func(tx repository.Repos) error {
+		existing, err := existingGerenteViolations(ctx, tx.Departamentos, s.logger, []uuid.UUID{id}, colaboradorIDs)
+		if err != nil {
+			return err
+		}
 
-	return &dto.ListDepartamentosResponse{
-		Data:       departamentos,
-		Total:      total,
-		Page:       page,
-		PageSize:   pageSize,
-		TotalPages: totalPages,
-	}, nil
+		if err := tx.Departamentos.Create(ctx, departamento); err != nil {
+			s.logger.Error("Failed to create departamento", zap.Error(err))
+			return apperror.Internal("Erro ao criar departamento", err)
+		}
+
+		if err := tx.Colaboradores.MoveToDepartamento(ctx, colaboradorIDs, departamento.ID); err != nil {
+			s.logger.Error("Failed to move colaboradores", zap.Error(err))
+			return apperror.Internal("Erro ao transferir colaboradores", err)
+		}
+
+		for _, subID := range subdepartamentoIDs {
+			hasCycle, err := tx.Departamentos.HasCycle(ctx, subID, departamento.ID)
+			if err != nil {
+				s.logger.Error("Failed to check cycle", zap.Error(err))
+				return apperror.Internal("Erro ao verificar ciclo na hierarquia", err)
+			}
+			if hasCycle {
+				s.logger.Warn("Cycle detected in hierarchy", zap.String("subdepartamento_id", subID.String()))
+				return apperror.ErrHierarchyCycle
+			}
+		}
+		if err := tx.Departamentos.SetSuperior(ctx, subdepartamentoIDs, departamento.ID); err != nil {
+			if errors.Is(err, apperror.ErrHierarchyCycle) {
+				s.logger.Warn("Cycle rejected by database", zap.String("id", id.String()))
+				return err
+			}
+			s.logger.Error("Failed to move subdepartamentos", zap.Error(err))
+			return apperror.Internal("Erro ao mover subdepartamentos", err)
+		}
+
+		return enforceGerenteInvariant(ctx, tx.Departamentos, s.logger, existing, []uuid.UUID{id, departamento.ID}, colaboradorIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.repo.GetByID(ctx, departamento.ID)
+	if err != nil {
+		s.logger.Error("Failed to get departamento", zap.Error(err))
+		return nil, apperror.Internal("Erro ao buscar departamento", err)
+	}
+
+	s.invalidate(ctx, append(superiorTags(departamento.DepartamentoSuperiorID), departamentoTag(id))...)
+
+	response := &dto.SplitDepartamentoResponse{
+		Departamento:            created,
+		Origem:                  dto.DepartamentoRef{ID: source.ID, Nome: source.Nome},
+		ColaboradoresMovidos:    make([]dto.DependentSummary, 0, len(colaboradores)),
+		SubdepartamentosMovidos: departamentoPath(subdepartamentos),
+	}
+	for _, c := range colaboradores {
+		response.ColaboradoresMovidos = append(response.ColaboradoresMovidos, dto.DependentSummary{ID: c.ID, Nome: c.Nome})
+	}
+
+	s.logger.Info("Departamento split successfully",
+		zap.String("id", id.String()),
+		zap.String("new_id", departamento.ID.String()),
+		zap.Int("colaboradores", len(colaboradores)),
+		zap.Int("subdepartamentos", len(subdepartamentos)))
+	return response, nil
 }
 
-func (s *departamentoService) GetColaboradoresByGerente(ctx context.Context, gerenteID uuid.UUID) ([]model.Colaborador, error) {
-	s.logger.Info("Getting colaboradores by gerente", zap.String("gerente_id", gerenteID.String()))
+func departamentoPath(departamentos []model.Departamento) []dto.DepartamentoRef {
+	path := make([]dto.DepartamentoRef, 0, len(departamentos))
+	for _, d := range departamentos {
+		path = append(path, dto.DepartamentoRef{ID: d.ID, Nome: d.Nome})
+	}
+	return path
+}
 
-	gerente, err := s.colabRepo.GetByID(ctx, gerenteID)
+// Delete removes a departamento after dealing with what hangs off it:
+// colaboradores are reassigned, direct subdepartamentos reparented, and with
+// opts.Cascade whatever is left is removed too. Without a way out for its
+// dependents the delete is refused. Every write runs in one transaction.
+func (s *departamentoService) Delete(ctx context.Context, id uuid.UUID, opts *dto.DeleteDepartamentoOptions) error {
+	s.logger.Info("Deleting departamento", zap.String("id", id.String()), zap.Bool("cascade", opts.Cascade))
+
+	departamento, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			s.logger.Warn("Gerente not found", zap.String("gerente_id", gerenteID.String()))
-			return nil, errors.New("Gerente não encontrado")
+			s.logger.Warn("Departamento not found", zap.String("id", id.String()))
+			return apperror.ErrDepartamentoNotFound
 		}
-		s.logger.Error("Failed to get gerente", zap.Error(err))
-		return nil, errors.New("Erro ao buscar gerente")
+		s.logger.Error("Failed to get departamento", zap.Error(err))
+		return apperror.Internal("Erro ao buscar departamento", err)
+	}
+
+	subtree, err := s.repo.GetSubdepartamentosRecursive(ctx, id)
+	if err != nil {
+		s.logger.Error("Failed to get subdepartamentos", zap.Error(err))
+		return apperror.Internal("Erro ao buscar subdepartamentos", err)
 	}
 
-	deptIDs, err := s.repo.GetSubdepartamentosRecursive(ctx, gerente.DepartamentoID)
+	children, err := s.repo.GetChildren(ctx, id)
 	if err != nil {
 		s.logger.Error("Failed to get subdepartamentos", zap.Error(err))
-		return nil, errors.New("Erro ao buscar subdepartamentos")
+		return apperror.Internal("Erro ao buscar subdepartamentos", err)
 	}
 
-	deptIDs = append(deptIDs, gerente.DepartamentoID)
+	removed := []uuid.UUID{id}
+	if opts.Cascade && opts.ReparentChildrenTo == nil {
+		removed = append(removed, subtree...)
+	}
 
-	colaboradores, err := s.colabRepo.GetByDepartamentoIDs(ctx, deptIDs)
+	affected, err := s.colabRepo.GetByDepartamentoIDs(ctx, append([]uuid.UUID{id}, subtree...))
 	if err != nil {
 		s.logger.Error("Failed to get colaboradores", zap.Error(err))
-		return nil, errors.New("Erro ao buscar colaboradores")
+		return apperror.Internal("Erro ao buscar colaboradores", err)
+	}
+	var colaboradores []model.Colaborador
+	for _, c := range affected {
+		if c.DepartamentoID == id {
+			colaboradores = append(colaboradores, c)
+		}
+	}
+
+	if err := s.validateDelete(ctx, id, opts, removed, subtree); err != nil {
+		return err
+	}
+	if err := s.checkDependents(id, opts, colaboradores, children); err != nil {
+		return err
 	}
 
-	s.logger.Info("Colaboradores retrieved successfully", zap.Int("count", len(colaboradores)))
-	return colaboradores, nil
+	// Colaboradores of removed departamentos are either moved or removed.
+	var touched []uuid.UUID
+	for _, c := range affected {
+		if slices.Contains(removed, c.DepartamentoID) {
+			touched = append(touched, c.ID)
+		}
+	}
+
+	err = s.uow.WithTx(ctx, func(tx repository.Repos) error {
+		existing, err := existingGerenteViolations(ctx, tx.Departamentos, s.logger, nil, touched)
+		if err != nil {
+			return err
+		}
+
+		// One instant for every row removed, so Restore can bring back
+		// the colaboradores that went with the departamento.
+		deletedAt := time.Now().Truncate(time.Microsecond)
+
+		if opts.ReassignColaboradoresTo != nil {
+			if err := tx.Colaboradores.Reassign(ctx, id, *opts.ReassignColaboradoresTo); err != nil {
+				s.logger.Error("Failed to reassign colaboradores", zap.Error(err))
+				return apperror.Internal("Erro ao transferir colaboradores", err)
+			}
+		}
+		if opts.ReparentChildrenTo != nil {
+			if err := tx.Departamentos.Reparent(ctx, id, *opts.ReparentChildrenTo); err != nil {
+				if errors.Is(err, apperror.ErrHierarchyCycle) {
+					s.logger.Warn("Cycle rejected by database", zap.String("id", id.String()))
+					return err
+				}
+				s.logger.Error("Failed to reparent subdepartamentos", zap.Error(err))
+				return apperror.Internal("Erro ao mover subdepartamentos", err)
+			}
+		}
+		if err := tx.Colaboradores.DeleteByDepartamentoIDs(ctx, removed, deletedAt); err != nil {
+			s.logger.Error("Failed to delete colaboradores", zap.Error(err))
+			return apperror.Internal("Erro ao deletar colaboradores", err)
+		}
+		if err := tx.Departamentos.DeleteByIDs(ctx, removed, deletedAt); err != nil {
+			s.logger.Error("Failed to delete departamento", zap.Error(err))
+			return apperror.Internal("Erro ao deletar departamento", err)
+		}
+		return enforceGerenteInvariant(ctx, tx.Departamentos, s.logger, existing, nil, touched)
+	})
+	if err != nil {
+		return err
+	}
+
+	stale := removed
+	if departamento.DepartamentoSuperiorID != nil {
+		stale = append(stale, *departamento.DepartamentoSuperiorID)
+	}
+	if opts.ReparentChildrenTo != nil {
+		stale = append(stale, *opts.ReparentChildrenTo)
+	}
+	if opts.ReassignColaboradoresTo != nil {
+		stale = append(stale, *opts.ReassignColaboradoresTo)
+	}
+	tags := make([]string, 0, len(stale))
+	for _, deptID := range stale {
+		tags = append(tags, departamentoTag(deptID))
+	}
+	s.invalidate(ctx, tags...)
+
+	s.logger.Info("Departamento deleted successfully", zap.String("id", id.String()), zap.Int("departamentos_removed", len(removed)))
+	return nil
+}
+
+// checkDependents refuses the delete when colaboradores or subdepartamentos
+// would be left behind, describing them in the error details.
+func (s *departamentoService) checkDependents(id uuid.UUID, opts *dto.DeleteDepartamentoOptions, colaboradores []model.Colaborador, children []model.Departamento) error {
+	strandedColaboradores := len(colaboradores) > 0 && opts.ReassignColaboradoresTo == nil && !opts.Cascade
+	strandedChildren := len(children) > 0 && opts.ReparentChildrenTo == nil && !opts.Cascade
+	if !strandedColaboradores && !strandedChildren {
+		return nil
+	}
+
+	dependents := dto.DepartamentoDependents{
+		Colaboradores:    make([]dto.DependentSummary, 0, len(colaboradores)),
+		Subdepartamentos: make([]dto.DependentSummary, 0, len(children)),
+	}
+	for _, c := range colaboradores {
+		dependents.Colaboradores = append(dependents.Colaboradores, dto.DependentSummary{ID: c.ID, Nome: c.Nome})
+	}
+	for _, d := range children {
+		dependents.Subdepartamentos = append(dependents.Subdepartamentos, dto.DependentSummary{ID: d.ID, Nome: d.Nome})
+	}
+
+	s.logger.Warn("Departamento has dependents",
+		zap.String("id", id.String()),
+		zap.Int("colaboradores", len(colaboradores)),
+		zap.Int("subdepartamentos", len(children)))
+	return apperror.ErrDepartamentoHasDependents.WithDetails(dependents)
+}
+
+func departamentoIDs(departamentos []model.Departamento) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(departamentos))
+	for _, d := range departamentos {
+		ids = append(ids, d.ID)
+	}
+	return ids
+}
+
+// Restore undoes a soft delete together with the colaboradores that delete
+// removed, so a cascade can be undone in one step. The superior must be
+// active so the hierarchy never points at removed records, and the gerente
+// is the departamento's own unless opts.GerenteID names someone else, who is
+// then moved in; see validateRestore. Restoring an active departamento is a
+// no-op.
+func (s *departamentoService) Restore(ctx context.Context, id uuid.UUID, opts *dto.RestoreDepartamentoOptions) (*model.Departamento, error) {
+	s.logger.Info("Restoring departamento", zap.String("id", id.String()))
+
+	departamento, err := s.repo.GetByIDUnscoped(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			s.logger.Warn("Departamento not found", zap.String("id", id.String()))
+			return nil, apperror.ErrDepartamentoNotFound
+		}
+		s.logger.Error("Failed to get departamento", zap.Error(err))
+		return nil, apperror.Internal("Erro ao buscar departamento", err)
+	}
+
+	stale := []string{departamentoTag(id)}
+	if departamento.DeletedAt.Valid {
+		gerente, err := s.validateRestore(ctx, departamento, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.uow.WithTx(ctx, func(tx repository.Repos) error {
+			existing, err := existingGerenteViolations(ctx, tx.Departamentos, s.logger, []uuid.UUID{id}, []uuid.UUID{gerente.ID})
+			if err != nil {
+				return err
+			}
+
+			if err := tx.Departamentos.Restore(ctx, id); err != nil {
+				s.logger.Error("Failed to restore departamento", zap.Error(err))
+				return apperror.Internal("Erro ao restaurar departamento", err)
+			}
+			if err := tx.Colaboradores.RestoreDeletedWith(ctx, id, departamento.DeletedAt.Time); err != nil {
+				s.logger.Error("Failed to restore colaboradores", zap.Error(err))
+				return apperror.Internal("Erro ao restaurar colaboradores", err)
+			}
+
+			if gerente.DepartamentoID != id {
+				if err := tx.Colaboradores.MoveToDepartamento(ctx, []uuid.UUID{gerente.ID}, id); err != nil {
+					s.logger.Error("Failed to move gerente", zap.Error(err))
+					return apperror.Internal("Erro ao transferir gerente", err)
+				}
+			}
+			if gerente.ID != departamento.GerenteID {
+				restored, err := tx.Departamentos.GetByID(ctx, id)
+				if err != nil {
+					s.logger.Error("Failed to get departamento", zap.Error(err))
+					return apperror.Internal("Erro ao buscar departamento", err)
+				}
+				now := time.Now()
+				restored.GerenteID = gerente.ID
+				restored.GerenteDesde = &now
+				if err := tx.Departamentos.Update(ctx, restored); err != nil {
+					if staleErr := staleVersionError(err, nil); staleErr != nil {
+						s.logger.Warn("Departamento changed during restore", zap.String("id", id.String()))
+						return staleErr
+					}
+					s.logger.Error("Failed to assign gerente", zap.Error(err))
+					return apperror.Internal("Erro ao atribuir gerente", err)
+				}
+			}
+
+			return enforceGerenteInvariant(ctx, tx.Departamentos, s.logger, existing, []uuid.UUID{id}, []uuid.UUID{gerente.ID})
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if gerente.DepartamentoID != id {
+			stale = append(stale, colaboradorTag(gerente.ID), departamentoTag(gerente.DepartamentoID))
+		}
+	}
+
+	restored, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("Failed to get departamento", zap.Error(err))
+		return nil, apperror.Internal("Erro ao buscar departamento", err)
+	}
+
+	s.invalidate(ctx, append(superiorTags(restored.DepartamentoSuperiorID), stale...)...)
+
+	s.logger.Info("Departamento restored successfully", zap.String("id", id.String()))
+	return restored, nil
+}
+
+func (s *departamentoService) List(ctx context.Context, filters map[string]interface{}, page, pageSize int) (*dto.ListDepartamentosResponse, error) {
+	s.logger.Info("Listing departamentos", zap.Int("page", page), zap.Int("page_size", pageSize))
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	params := listParams{Filters: normalizeListFilters(filters, departamentoListFilters), Page: page, PageSize: pageSize}
+	return s.lists.Get(ctx, params, func(ctx context.Context) (*dto.ListDepartamentosResponse, error) {
+		departamentos, total, err := s.repo.List(ctx, filters, page, pageSize)
+		if err != nil {
+			s.logger.Error("Failed to list departamentos", zap.Error(err))
+			return nil, apperror.Internal("Erro ao listar departamentos", err)
+		}
+
+		totalPages := int(total) / pageSize
+		if int(total)%pageSize > 0 {
+			totalPages++
+		}
+
+		s.logger.Info("Departamentos listed successfully", zap.Int64("total", total))
+
+		return &dto.ListDepartamentosResponse{
+			Data:       departamentos,
+			Total:      total,
+			Page:       page,
+			PageSize:   pageSize,
+			TotalPages: totalPages,
+		}, nil
+	})
+}
+
+func (s *departamentoService) GetColaboradoresByGerente(ctx context.Context, gerenteID uuid.UUID) ([]model.Colaborador, error) {
+	s.logger.Info("Getting colaboradores by gerente", zap.String("gerente_id", gerenteID.String()))
+
+	colaboradores, err := s.byGerente.Get(ctx, gerenteID, func(ctx context.Context) (*[]model.Colaborador, error) {
+		gerente, err := s.colabRepo.GetByID(ctx, gerenteID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				s.logger.Warn("Gerente not found", zap.String("gerente_id", gerenteID.String()))
+				return nil, apperror.ErrGerenteNotFound
+			}
+			s.logger.Error("Failed to get gerente", zap.Error(err))
+			return nil, apperror.Internal("Erro ao buscar gerente", err)
+		}
+
+		deptIDs, err := s.repo.GetSubdepartamentosRecursive(ctx, gerente.DepartamentoID)
+		if err != nil {
+			s.logger.Error("Failed to get subdepartamentos", zap.Error(err))
+			return nil, apperror.Internal("Erro ao buscar subdepartamentos", err)
+		}
+
+		deptIDs = append(deptIDs, gerente.DepartamentoID)
+
+		colaboradores, err := s.colabRepo.GetByDepartamentoIDs(ctx, deptIDs)
+		if err != nil {
+			s.logger.Error("Failed to get colaboradores", zap.Error(err))
+			return nil, apperror.Internal("Erro ao buscar colaboradores", err)
+		}
+		return &colaboradores, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Colaboradores retrieved successfully", zap.Int("count", len(*colaboradores)))
+	return *colaboradores, nil
+}
+
+// invalidate retires the write stamp, dropping every cached list, then the
+// cached entries carrying tags.
+func (s *departamentoService) invalidate(ctx context.Context, tags ...string) {
+	retireWriteStamp(ctx, s.cache)
+	s.cache.InvalidateTags(ctx, tags...)
 }
@@ -0,0 +1,423 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"takehome-go/internal/apperror"
+	"takehome-go/internal/dto"
+	"takehome-go/internal/model"
+)
+
+// validateCreate evaluates every rule for a new departamento and returns the
+// gerente it resolved along the way. Only infrastructure failures
+// short-circuit; rule failures are reported together.
+func (s *departamentoService) validateCreate(ctx context.Context, req *dto.CreateDepartamentoRequest) (*model.Colaborador, error) {
+	v := apperror.NewViolations()
+
+	gerente, err := s.checkCreate(ctx, v, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+	return gerente, nil
+}
+
+// checkCreate records the rules of validateCreate into v, so operations that
+// create a departamento as one of several steps report them together with
+// their own.
+func (s *departamentoService) checkCreate(ctx context.Context, v *apperror.Violations, req *dto.CreateDepartamentoRequest) (*model.Colaborador, error) {
+	gerente, err := s.checkGerente(ctx, v, req.GerenteID)
+	if err != nil {
+		return nil, err
+	}
+	if gerente != nil {
+		if err := s.checkManagesOther(ctx, v, gerente.ID); err != nil {
+			return nil, err
+		}
+	}
+	if req.DepartamentoSuperiorID != nil {
+		if _, err := s.checkSuperior(ctx, v, uuid.Nil, *req.DepartamentoSuperiorID); err != nil {
+			return nil, err
+		}
+	}
+	return gerente, nil
+}
+
+// validateSplit applies the create rules to the new departamento and checks
+// that everything moving to it comes from the source: colaboradores working
+// there and direct subdepartamentos. The source's own gerente has to stay.
+func (s *departamentoService) validateSplit(ctx context.Context, source *model.Departamento, req *dto.SplitDepartamentoRequest, create *dto.CreateDepartamentoRequest) ([]model.Colaborador, []model.Departamento, error) {
+	v := apperror.NewViolations()
+
+	if _, err := s.checkCreate(ctx, v, create); err != nil {
+		return nil, nil, err
+	}
+	if !slices.Contains(req.ColaboradorIDs, req.GerenteID) {
+		s.logger.Warn("Split gerente not among moved colaboradores", zap.String("gerente_id", req.GerenteID.String()))
+		v.Add(apperror.ErrSplitGerenteNotMoved)
+	}
+
+	members, err := s.colabRepo.GetByDepartamentoIDs(ctx, []uuid.UUID{source.ID})
+	if err != nil {
+		s.logger.Error("Failed to get colaboradores", zap.Error(err))
+		return nil, nil, apperror.Internal("Erro ao buscar colaboradores", err)
+	}
+	var colaboradores []model.Colaborador
+	for i, id := range req.ColaboradorIDs {
+		field := fmt.Sprintf("colaborador_ids[%d]", i)
+		idx := slices.IndexFunc(members, func(c model.Colaborador) bool { return c.ID == id })
+		switch {
+		case idx < 0:
+			s.logger.Warn("Colaborador not in source department", zap.String("colaborador_id", id.String()))
+			v.Add(apperror.ErrSplitColaboradorOutside.WithField(field))
+		case id == source.GerenteID:
+			s.logger.Warn("Source gerente cannot leave", zap.String("colaborador_id", id.String()))
+			v.Add(apperror.ErrGerenteCannotLeave.WithField(field))
+		default:
+			colaboradores = append(colaboradores, members[idx])
+		}
+	}
+
+	children, err := s.repo.GetChildren(ctx, source.ID)
+	if err != nil {
+		s.logger.Error("Failed to get subdepartamentos", zap.Error(err))
+		return nil, nil, apperror.Internal("Erro ao buscar subdepartamentos", err)
+	}
+	var subdepartamentos []model.Departamento
+	for i, id := range req.SubdepartamentoIDs {
+		idx := slices.IndexFunc(children, func(d model.Departamento) bool { return d.ID == id })
+		if idx < 0 {
+			s.logger.Warn("Subdepartamento not a child of source", zap.String("subdepartamento_id", id.String()))
+			v.Add(apperror.ErrSplitSubdeptOutside.WithField(fmt.Sprintf("subdepartamento_ids[%d]", i)))
+			continue
+		}
+		subdepartamentos = append(subdepartamentos, children[idx])
+	}
+
+	if err := v.Err(); err != nil {
+		return nil, nil, err
+	}
+	return colaboradores, subdepartamentos, nil
+}
+
+// validateBootstrap applies the colaborador rules to the new gerente, reported
+// under "gerente.*", plus the departamento rules.
+func (s *departamentoService) validateBootstrap(ctx context.Context, req *dto.BootstrapDepartamentoRequest) error {
+	v := apperror.NewViolations()
+
+	gv := v.Nested("gerente")
+	if err := checkCPF(ctx, s.colabRepo, s.logger, gv, req.Gerente.CPF, nil); err != nil {
+		return err
+	}
+	if req.Gerente.RG != nil && *req.Gerente.RG != "" {
+		if err := checkRG(ctx, s.colabRepo, s.logger, gv, *req.Gerente.RG, nil); err != nil {
+			return err
+		}
+	}
+
+	if req.DepartamentoSuperiorID != nil {
+		if _, err := s.checkSuperior(ctx, v, uuid.Nil, *req.DepartamentoSuperiorID); err != nil {
+			return err
+		}
+	}
+
+	return v.Err()
+}
+
+// validatePatch checks only the members present in the patch. A null
+// superior turns the department into a root and needs no check.
+func (s *departamentoService) validatePatch(ctx context.Context, id uuid.UUID, req *dto.PatchDepartamentoRequest) error {
+	v := apperror.NewViolations()
+
+	if req.Nome.Set && (req.Nome.Null || strings.TrimSpace(req.Nome.Value) == "") {
+		v.Add(apperror.ErrNomeRequired)
+	}
+
+	if req.GerenteID.Set {
+		if req.GerenteID.Null {
+			v.Add(apperror.ErrGerenteRequired)
+		} else {
+			gerente, err := s.checkGerente(ctx, v, req.GerenteID.Value)
+			if err != nil {
+				return err
+			}
+			if gerente != nil && gerente.DepartamentoID != id {
+				s.logger.Warn("Gerente not in same department", zap.String("gerente_id", req.GerenteID.Value.String()))
+				v.Add(apperror.ErrGerenteOutsideDepartamento)
+			}
+		}
+	}
+
+	if req.DepartamentoSuperiorID.Set && !req.DepartamentoSuperiorID.Null {
+		if _, err := s.checkSuperior(ctx, v, id, req.DepartamentoSuperiorID.Value); err != nil {
+			return err
+		}
+	}
+
+	return v.Err()
+}
+
+// validateRestore returns the gerente the departamento comes back with and
+// requires the superior, if any, to be active. Colaboradores removed along
+// with the departamento are restored with it, so a gerente removed by a
+// cascade qualifies. Anyone else must be active; the departamento's own
+// gerente must still work there, while one chosen through opts.GerenteID is
+// moved in unless they manage another departamento.
+func (s *departamentoService) validateRestore(ctx context.Context, departamento *model.Departamento, opts *dto.RestoreDepartamentoOptions) (*model.Colaborador, error) {
+	v := apperror.NewViolations()
+
+	gerenteID := departamento.GerenteID
+	if opts.GerenteID != nil {
+		gerenteID = *opts.GerenteID
+	}
+
+	gerente, err := s.colabRepo.GetByIDUnscoped(ctx, gerenteID)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		s.logger.Warn("Gerente not found", zap.String("gerente_id", gerenteID.String()))
+		v.Add(apperror.ErrGerenteNotFound)
+	case err != nil:
+		s.logger.Error("Failed to get gerente", zap.Error(err))
+		return nil, apperror.Internal("Erro ao buscar gerente", err)
+	case gerente.DeletedAt.Valid && !deletedWith(gerente, departamento):
+		s.logger.Warn("Gerente is deleted", zap.String("gerente_id", gerenteID.String()))
+		v.Add(apperror.ErrGerenteDeleted)
+	case gerente.DepartamentoID != departamento.ID && opts.GerenteID == nil:
+		s.logger.Warn("Gerente was transferred", zap.String("gerente_id", gerenteID.String()))
+		v.Add(apperror.ErrGerenteTransferred)
+	case gerente.DepartamentoID != departamento.ID:
+		if err := s.checkManagesOther(ctx, v, gerente.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if departamento.DepartamentoSuperiorID != nil {
+		if _, err := s.repo.GetByID(ctx, *departamento.DepartamentoSuperiorID); err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				s.logger.Error("Failed to get superior department", zap.Error(err))
+				return nil, apperror.Internal("Erro ao buscar departamento superior", err)
+			}
+			s.logger.Warn("Superior department is deleted", zap.String("departamento_superior_id", departamento.DepartamentoSuperiorID.String()))
+			v.Add(apperror.ErrDepartamentoSuperiorDeleted)
+		}
+	}
+
+	return gerente, v.Err()
+}
+
+// deletedWith reports whether the colaborador was removed by the same
+// delete as the departamento, which stamps both with one deleted_at.
+func deletedWith(colaborador *model.Colaborador, departamento *model.Departamento) bool {
+	return colaborador.DeletedAt.Valid &&
+		colaborador.DepartamentoID == departamento.ID &&
+		colaborador.DeletedAt.Time.Equal(departamento.DeletedAt.Time)
+}
+
+// effectiveDateSkew tolerates clients whose clock runs slightly ahead when
+// they send the current time as effective_date.
+const effectiveDateSkew = time.Minute
+
+// validateChangeGerente returns the incoming gerente. Someone who works in
+// another departamento may be brought in, but not while managing it.
+func (s *departamentoService) validateChangeGerente(ctx context.Context, departamento *model.Departamento, req *dto.ChangeGerenteRequest) (*model.Colaborador, error) {
+	v := apperror.NewViolations()
+
+	if req.GerenteID == departamento.GerenteID {
+		s.logger.Warn("Gerente unchanged", zap.String("gerente_id", req.GerenteID.String()))
+		v.Add(apperror.ErrGerenteUnchanged)
+	}
+
+	gerente, err := s.checkGerente(ctx, v, req.GerenteID)
+	if err != nil {
+		return nil, err
+	}
+	if gerente != nil && gerente.DepartamentoID != departamento.ID {
+		if err := s.checkManagesOther(ctx, v, gerente.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Scheduled successions are not supported: the swap happens now, so a
+	// future date would record a gerente_desde that has not happened yet.
+	if req.EffectiveDate != nil && req.EffectiveDate.After(time.Now().Add(effectiveDateSkew)) {
+		s.logger.Warn("Effective date in the future", zap.Time("effective_date", *req.EffectiveDate))
+		v.Add(apperror.ErrEffectiveDateFuture)
+	}
+
+	if target := req.RelocateOutgoingTo; target != nil {
+		if *target == departamento.ID {
+			s.logger.Warn("Relocate target is the same department", zap.String("relocate_outgoing_to", target.String()))
+			v.Add(apperror.ErrRelocateTargetInvalid)
+		} else if err := s.checkTarget(ctx, v, *target, apperror.ErrRelocateTargetNotFound); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+	return gerente, nil
+}
+
+// validateMove checks the new superior, if any, with the same rules as an
+// update, including the cycle check.
+func (s *departamentoService) validateMove(ctx context.Context, departamento *model.Departamento, req *dto.MoveDepartamentoRequest) error {
+	v := apperror.NewViolations()
+
+	current, next := departamento.DepartamentoSuperiorID, req.DepartamentoSuperiorID
+	if (current == nil && next == nil) || (current != nil && next != nil && *current == *next) {
+		s.logger.Warn("Superior department unchanged", zap.String("id", departamento.ID.String()))
+		v.Add(apperror.ErrSuperiorUnchanged)
+	} else if next != nil {
+		if _, err := s.checkSuperior(ctx, v, departamento.ID, *next); err != nil {
+			return err
+		}
+	}
+
+	return v.Err()
+}
+
+// validateMerge returns the target departamento. It must be another active
+// departamento outside the source's subtree, since the source's children
+// are reparented under it.
+func (s *departamentoService) validateMerge(ctx context.Context, source *model.Departamento, req *dto.MergeDepartamentoRequest) (*model.Departamento, error) {
+	if req.TargetID == source.ID {
+		s.logger.Warn("Merge target is the source", zap.String("target_id", req.TargetID.String()))
+		return nil, apperror.ErrMergeTargetInvalid
+	}
+
+	target, err := s.repo.GetByID(ctx, req.TargetID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			s.logger.Warn("Merge target not found", zap.String("target_id", req.TargetID.String()))
+			return nil, apperror.ErrMergeTargetNotFound
+		}
+		s.logger.Error("Failed to get merge target", zap.Error(err))
+		return nil, apperror.Internal("Erro ao buscar departamento de destino", err)
+	}
+
+	insideSource, err := s.repo.HasCycle(ctx, source.ID, target.ID)
+	if err != nil {
+		s.logger.Error("Failed to check cycle", zap.Error(err))
+		return nil, apperror.Internal("Erro ao verificar ciclo na hierarquia", err)
+	}
+	if insideSource {
+		s.logger.Warn("Merge target inside source subtree", zap.String("target_id", target.ID.String()))
+		return nil, apperror.ErrMergeTargetInvalid
+	}
+
+	return target, nil
+}
+
+// validateDelete checks the targets chosen for the departamento's dependents.
+// Neither may be removed by the same operation, and the new superior may not
+// sit inside the departamento's own subtree.
+func (s *departamentoService) validateDelete(ctx context.Context, id uuid.UUID, opts *dto.DeleteDepartamentoOptions, removed, subtree []uuid.UUID) error {
+	v := apperror.NewViolations()
+
+	if target := opts.ReassignColaboradoresTo; target != nil {
+		if slices.Contains(removed, *target) {
+			s.logger.Warn("Reassign target would be removed", zap.String("reassign_colaboradores_to", target.String()))
+			v.Add(apperror.ErrReassignTargetInvalid)
+		} else if err := s.checkTarget(ctx, v, *target, apperror.ErrReassignTargetNotFound); err != nil {
+			return err
+		}
+	}
+
+	if target := opts.ReparentChildrenTo; target != nil {
+		if *target == id || slices.Contains(subtree, *target) {
+			s.logger.Warn("Reparent target inside subtree", zap.String("reparent_children_to", target.String()))
+			v.Add(apperror.ErrReparentTargetInvalid)
+		} else if err := s.checkTarget(ctx, v, *target, apperror.ErrReparentTargetNotFound); err != nil {
+			return err
+		}
+	}
+
+	return v.Err()
+}
+
+func (s *departamentoService) checkTarget(ctx context.Context, v *apperror.Violations, id uuid.UUID, notFound *apperror.Error) error {
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			s.logger.Warn("Target department not found", zap.String("departamento_id", id.String()))
+			v.Add(notFound)
+			return nil
+		}
+		s.logger.Error("Failed to get target department", zap.Error(err))
+		return apperror.Internal("Erro ao buscar departamento de destino", err)
+	}
+	return nil
+}
+
+// checkGerente returns the gerente when it exists, or records a violation and
+// returns nil.
+func (s *departamentoService) checkGerente(ctx context.Context, v *apperror.Violations, gerenteID uuid.UUID) (*model.Colaborador, error) {
+	gerente, err := s.colabRepo.GetByID(ctx, gerenteID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			s.logger.Warn("Gerente not found", zap.String("gerente_id", gerenteID.String()))
+			v.Add(apperror.ErrGerenteNotFound)
+			return nil, nil
+		}
+		s.logger.Error("Failed to get gerente", zap.Error(err))
+		return nil, apperror.Internal("Erro ao buscar gerente", err)
+	}
+	return gerente, nil
+}
+
+// checkManagesOther records a violation when the gerente already manages a
+// departamento, since moving them away would leave it without its gerente.
+func (s *departamentoService) checkManagesOther(ctx context.Context, v *apperror.Violations, gerenteID uuid.UUID) error {
+	managed, err := s.repo.GetByGerenteID(ctx, gerenteID)
+	if err != nil {
+		s.logger.Error("Failed to get managed departments", zap.Error(err))
+		return apperror.Internal("Erro ao buscar departamentos gerenciados", err)
+	}
+	if len(managed) > 0 {
+		s.logger.Warn("Gerente manages another department", zap.String("gerente_id", gerenteID.String()))
+		v.Add(apperror.ErrGerenteManagesOther)
+	}
+	return nil
+}
+
+// checkSuperior verifies the superior exists and, when id is set, that using
+// it would not close a loop in the hierarchy.
+func (s *departamentoService) checkSuperior(ctx context.Context, v *apperror.Violations, id, superiorID uuid.UUID) (*model.Departamento, error) {
+	superior, err := s.repo.GetByID(ctx, superiorID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			s.logger.Warn("Superior department not found", zap.String("departamento_superior_id", superiorID.String()))
+			v.Add(apperror.ErrDepartamentoSuperiorNotFound)
+			return nil, nil
+		}
+		s.logger.Error("Failed to get superior department", zap.Error(err))
+		return nil, apperror.Internal("Erro ao buscar departamento superior", err)
+	}
+
+	if id == uuid.Nil {
+		return superior, nil
+	}
+
+	hasCycle, err := s.repo.HasCycle(ctx, id, superiorID)
+	if err != nil {
+		s.logger.Error("Failed to check cycle", zap.Error(err))
+		return nil, apperror.Internal("Erro ao verificar ciclo na hierarquia", err)
+	}
+	if hasCycle {
+		s.logger.Warn("Cycle detected in hierarchy", zap.String("departamento_superior_id", superiorID.String()))
+		v.Add(apperror.ErrHierarchyCycle)
+	}
+	return superior, nil
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"takehome-go/internal/apperror"
+	"takehome-go/internal/dto"
+	"takehome-go/internal/model"
+)
+
+func TestValidateChangeGerenteEffectiveDate(t *testing.T) {
+	departamento := &model.Departamento{ID: uuid.New(), GerenteID: uuid.New()}
+	incoming := &model.Colaborador{ID: uuid.New(), DepartamentoID: departamento.ID}
+	svc := &departamentoService{
+		colabRepo: colaboradoresByID{byID: map[uuid.UUID]*model.Colaborador{incoming.ID: incoming}},
+		logger:    zap.NewNop(),
+	}
+
+	at := func(d time.Duration) *time.Time {
+		date := time.Now().Add(d)
+		return &date
+	}
+
+	tests := []struct {
+		name          string
+		effectiveDate *time.Time
+		wantErr       error
+	}{
+		{name: "defaults to now"},
+		{name: "past date", effectiveDate: at(-30 * 24 * time.Hour)},
+		{name: "now with a client clock slightly ahead", effectiveDate: at(10 * time.Second)},
+		{name: "future date", effectiveDate: at(24 * time.Hour), wantErr: apperror.ErrEffectiveDateFuture},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &dto.ChangeGerenteRequest{GerenteID: incoming.ID, EffectiveDate: tt.effectiveDate}
+			_, err := svc.validateChangeGerente(context.Background(), departamento, req)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"takehome-go/internal/apperror"
+	"takehome-go/internal/dto"
+	"takehome-go/internal/repository"
+)
+
+// The rule that a departamento's gerente is one of its own active
+// colaboradores is guarded twice. Validation calls checkCanLeave to report a
+// friendly error up front, and every write path calls enforceGerenteInvariant
+// inside its transaction, after writing, so nothing that slips past
+// validation (or a concurrent write) is ever committed. Violations already
+// in the data before the write are not held against it: they are recorded
+// with existingGerenteViolations at the start of the transaction, so data
+// predating the rule does not block unrelated writes such as a rename.
+
+// gerenteBaseline holds the violations found before a write.
+type gerenteBaseline map[gerenteViolationKey]struct{}
+
+// gerenteViolationKey identifies a violation by the relationship that breaks
+// the rule, so moving the gerente elsewhere or swapping it counts as new.
+type gerenteViolationKey struct {
+	departamentoID        uuid.UUID
+	gerenteID             uuid.UUID
+	gerenteDepartamentoID uuid.UUID
+	reason                string
+}
+
+func violationKey(v repository.GerenteViolation) gerenteViolationKey {
+	key := gerenteViolationKey{departamentoID: v.DepartamentoID, reason: v.Reason}
+	if v.GerenteID != nil {
+		key.gerenteID = *v.GerenteID
+	}
+	if v.GerenteDepartamentoID != nil {
+		key.gerenteDepartamentoID = *v.GerenteDepartamentoID
+	}
+	return key
+}
+
+// existingGerenteViolations records the violations among departamentoIDs and
+// the departamentos managed by gerenteIDs before the write runs.
+func existingGerenteViolations(ctx context.Context, repo repository.DepartamentoRepository, logger *zap.Logger, departamentoIDs, gerenteIDs []uuid.UUID) (gerenteBaseline, error) {
+	if len(departamentoIDs) == 0 && len(gerenteIDs) == 0 {
+		return nil, nil
+	}
+
+	violations, err := repo.FindGerenteViolations(ctx, departamentoIDs, gerenteIDs)
+	if err != nil {
+		logger.Error("Failed to check gerente invariant", zap.Error(err))
+		return nil, apperror.Internal("Erro ao verificar gerentes dos departamentos", err)
+	}
+
+	existing := make(gerenteBaseline, len(violations))
+	for _, v := range violations {
+		existing[violationKey(v)] = struct{}{}
+	}
+	return existing, nil
+}
+
+// enforceGerenteInvariant fails with ErrGerenteInvariant, rolling back the
+// surrounding transaction, when any departamento in departamentoIDs or
+// managed by gerenteIDs breaks the rule in a way not already in existing.
+func enforceGerenteInvariant(ctx context.Context, repo repository.DepartamentoRepository, logger *zap.Logger, existing gerenteBaseline, departamentoIDs, gerenteIDs []uuid.UUID) error {
+	if len(departamentoIDs) == 0 && len(gerenteIDs) == 0 {
+		return nil
+	}
+
+	violations, err := repo.FindGerenteViolations(ctx, departamentoIDs, gerenteIDs)
+	if err != nil {
+		logger.Error("Failed to check gerente invariant", zap.Error(err))
+		return apperror.Internal("Erro ao verificar gerentes dos departamentos", err)
+	}
+
+	introduced := violations[:0]
+	for _, v := range violations {
+		if _, ok := existing[violationKey(v)]; !ok {
+			introduced = append(introduced, v)
+		}
+	}
+	if len(introduced) == 0 {
+		return nil
+	}
+
+	logger.Warn("Write would break gerente invariant", zap.Int("violations", len(introduced)))
+	return apperror.ErrGerenteInvariant.WithDetails(gerenteViolations(introduced))
+}
+
+// checkCanLeave records a violation when the colaborador manages a
+// departamento other than the one they are moving to.
+func checkCanLeave(ctx context.Context, repo repository.DepartamentoRepository, logger *zap.Logger, v *apperror.Violations, colaboradorID, departamentoID uuid.UUID) error {
+	managed, err := repo.GetByGerenteID(ctx, colaboradorID)
+	if err != nil {
+		logger.Error("Failed to get managed departments", zap.Error(err))
+		return apperror.Internal("Erro ao buscar departamentos gerenciados", err)
+	}
+	for _, d := range managed {
+		if d.ID != departamentoID {
+			logger.Warn("Gerente cannot leave managed department",
+				zap.String("colaborador_id", colaboradorID.String()),
+				zap.String("departamento_id", d.ID.String()))
+			v.Add(apperror.ErrGerenteCannotLeave)
+			return nil
+		}
+	}
+	return nil
+}
+
+func gerenteViolations(violations []repository.GerenteViolation) []dto.GerenteViolation {
+	out := make([]dto.GerenteViolation, 0, len(violations))
+	for _, v := range violations {
+		out = append(out, dto.GerenteViolation{
+			DepartamentoID:        v.DepartamentoID,
+			DepartamentoNome:      v.DepartamentoNome,
+			GerenteID:             v.GerenteID,
+			GerenteDepartamentoID: v.GerenteDepartamentoID,
+			Reason:                v.Reason,
+		})
+	}
+	return out
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"takehome-go/internal/apperror"
+	"takehome-go/internal/dto"
+	"takehome-go/internal/model"
+	"takehome-go/internal/orgchart"
+	"takehome-go/internal/repository"
+)
+
+// GetOrganograma returns every root departamento with its tree, or only the
+// branch named by opts.Cursor, down to opts.MaxDepth levels. Nodes whose
+// subdepartamentos were cut off carry a cursor to expand them later.
+func (s *departamentoService) GetOrganograma(ctx context.Context, opts *dto.OrganogramaOptions) (*dto.OrganogramaResponse, error) {
+	s.logger.Info("Getting organograma")
+
+	nodes, err := s.repo.GetTree(ctx, opts.Cursor, opts.MaxDepth)
+	if err != nil {
+		s.logger.Error("Failed to get organograma", zap.Error(err))
+		return nil, apperror.Internal("Erro ao buscar organograma", err)
+	}
+	if len(nodes) == 0 && opts.Cursor != nil {
+		s.logger.Warn("Departamento not found", zap.String("id", opts.Cursor.String()))
+		return nil, apperror.ErrDepartamentoNotFound
+	}
+
+	ids := make([]uuid.UUID, 0, len(nodes))
+	for _, n := range nodes {
+		ids = append(ids, n.Departamento.ID)
+	}
+
+	var headcounts map[uuid.UUID]int64
+	if opts.IncludeHeadcount && len(ids) > 0 {
+		headcounts, err = s.colabRepo.CountByDepartamentoIDs(ctx, ids)
+		if err != nil {
+			s.logger.Error("Failed to count colaboradores", zap.Error(err))
+			return nil, apperror.Internal("Erro ao contar colaboradores", err)
+		}
+	}
+
+	members := make(map[uuid.UUID][]model.Colaborador)
+	if opts.IncludeColaboradores && len(ids) > 0 {
+		colaboradores, err := s.colabRepo.GetByDepartamentoIDs(ctx, ids)
+		if err != nil {
+			s.logger.Error("Failed to get colaboradores", zap.Error(err))
+			return nil, apperror.Internal("Erro ao buscar colaboradores", err)
+		}
+		for _, c := range colaboradores {
+			c.Departamento = nil
+			members[c.DepartamentoID] = append(members[c.DepartamentoID], c)
+		}
+	}
+
+	var roots []*dto.OrganogramaNode
+	children := make(map[uuid.UUID][]*dto.OrganogramaNode)
+	expanded := make(map[uuid.UUID]bool, len(nodes))
+	for _, n := range nodes {
+		expanded[n.Departamento.ID] = true
+	}
+
+	for _, n := range nodes {
+		node := organogramaNode(n, members[n.Departamento.ID])
+		if opts.IncludeHeadcount {
+			headcount := headcounts[n.Departamento.ID]
+			node.Headcount = &headcount
+		}
+
+		superiorID := n.Departamento.DepartamentoSuperiorID
+		if superiorID != nil && expanded[*superiorID] {
+			children[*superiorID] = append(children[*superiorID], node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+
+	response := &dto.OrganogramaResponse{Data: make([]dto.OrganogramaNode, 0, len(roots))}
+	for _, root := range roots {
+		response.Data = append(response.Data, nestOrganograma(root, children))
+	}
+
+	s.logger.Info("Organograma retrieved successfully", zap.Int("departamentos", len(nodes)))
+	return response, nil
+}
+
+func organogramaNode(n repository.TreeNode, colaboradores []model.Colaborador) *dto.OrganogramaNode {
+	node := &dto.OrganogramaNode{
+		ID:                     n.Departamento.ID,
+		Nome:                   n.Departamento.Nome,
+		Gerente:                n.Departamento.Gerente,
+		DepartamentoSuperiorID: n.Departamento.DepartamentoSuperiorID,
+		Depth:                  *n.Departamento.Depth,
+		Colaboradores:          colaboradores,
+		Subdepartamentos:       []dto.OrganogramaNode{},
+	}
+	if n.HasChildren {
+		node.Cursor = dto.EncodeOrganogramaCursor(n.Departamento.ID)
+	}
+	return node
+}
+
+// nestOrganograma attaches children depth-first so every node is complete
+// before it is copied into its parent.
+func nestOrganograma(node *dto.OrganogramaNode, children map[uuid.UUID][]*dto.OrganogramaNode) dto.OrganogramaNode {
+	for _, child := range children[node.ID] {
+		node.Subdepartamentos = append(node.Subdepartamentos, nestOrganograma(child, children))
+	}
+	if len(node.Subdepartamentos) > 0 {
+		node.Cursor = ""
+	}
+	return *node
+}
+
+// ExportOrganograma draws the whole organisation, with gerentes and
+// headcounts, in one of the dto.ExportFormat* formats.
+func (s *departamentoService) ExportOrganograma(ctx context.Context, format string) ([]byte, error) {
+	s.logger.Info("Exporting organograma", zap.String("format", format))
+
+	nodes, err := s.repo.GetTree(ctx, nil, nil)
+	if err != nil {
+		s.logger.Error("Failed to get organograma", zap.Error(err))
+		return nil, apperror.Internal("Erro ao buscar organograma", err)
+	}
+
+	ids := make([]uuid.UUID, 0, len(nodes))
+	for _, n := range nodes {
+		ids = append(ids, n.Departamento.ID)
+	}
+
+	headcounts := map[uuid.UUID]int64{}
+	if len(ids) > 0 {
+		headcounts, err = s.colabRepo.CountByDepartamentoIDs(ctx, ids)
+		if err != nil {
+			s.logger.Error("Failed to count colaboradores", zap.Error(err))
+			return nil, apperror.Internal("Erro ao contar colaboradores", err)
+		}
+	}
+
+	var roots []*orgchart.Node
+	chartNodes := make(map[uuid.UUID]*orgchart.Node, len(nodes))
+	for _, n := range nodes {
+		node := &orgchart.Node{
+			ID:        n.Departamento.ID,
+			Nome:      n.Departamento.Nome,
+			Headcount: headcounts[n.Departamento.ID],
+		}
+		if n.Departamento.Gerente != nil {
+			node.Gerente = n.Departamento.Gerente.Nome
+		}
+		chartNodes[node.ID] = node
+
+		// GetTree lists parents before their children.
+		if superiorID := n.Departamento.DepartamentoSuperiorID; superiorID != nil {
+			if parent, ok := chartNodes[*superiorID]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+
+	var body []byte
+	switch format {
+	case dto.ExportFormatDOT:
+		body = orgchart.DOT(roots)
+	case dto.ExportFormatMermaid:
+		body = orgchart.Mermaid(roots)
+	default:
+		body = orgchart.SVG(roots)
+	}
+
+	s.logger.Info("Organograma exported successfully", zap.Int("departamentos", len(nodes)))
+	return body, nil
+}
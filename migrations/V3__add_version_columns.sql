@@ -0,0 +1,5 @@
+ALTER TABLE colaboradores
+ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+
+ALTER TABLE departamentos
+ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,8 @@
+ALTER TABLE colaboradores
+ADD COLUMN deleted_at TIMESTAMP;
+
+ALTER TABLE departamentos
+ADD COLUMN deleted_at TIMESTAMP;
+
+CREATE INDEX idx_colaboradores_deleted_at ON colaboradores(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_departamentos_deleted_at ON departamentos(deleted_at) WHERE deleted_at IS NOT NULL;
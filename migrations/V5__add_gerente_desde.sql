@@ -0,0 +1,4 @@
+ALTER TABLE departamentos
+ADD COLUMN gerente_desde TIMESTAMP;
+
+UPDATE departamentos SET gerente_desde = created_at WHERE gerente_desde IS NULL;
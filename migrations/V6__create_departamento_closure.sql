@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS departamento_closure (
+    ancestor_id UUID NOT NULL,
+    descendant_id UUID NOT NULL,
+    depth INT NOT NULL,
+    PRIMARY KEY (ancestor_id, descendant_id),
+    FOREIGN KEY (ancestor_id) REFERENCES departamentos(id) ON DELETE CASCADE,
+    FOREIGN KEY (descendant_id) REFERENCES departamentos(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_departamento_closure_descendant ON departamento_closure(descendant_id, depth);
+
+WITH RECURSIVE closure AS (
+    SELECT id AS ancestor_id, id AS descendant_id, 0 AS depth, ARRAY[id] AS caminho
+    FROM departamentos
+
+    UNION ALL
+
+    SELECT c.ancestor_id, d.id, c.depth + 1, c.caminho || d.id
+    FROM closure c
+    INNER JOIN departamentos d ON d.departamento_superior_id = c.descendant_id
+    WHERE NOT d.id = ANY(c.caminho)
+)
+INSERT INTO departamento_closure (ancestor_id, descendant_id, depth)
+SELECT ancestor_id, descendant_id, depth FROM closure
+ON CONFLICT DO NOTHING;
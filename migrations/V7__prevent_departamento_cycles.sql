@@ -0,0 +1,42 @@
+-- Rejects any write that would make a departamento its own ancestor, whatever
+-- the source (API, manual SQL or bulk imports). Hierarchy changes take a
+-- transaction-level advisory lock first, so two concurrent moves cannot each
+-- pass the check against the other's stale state and close a loop together.
+CREATE OR REPLACE FUNCTION departamentos_prevent_cycle() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_advisory_xact_lock(hashtext('departamentos_hierarchy'));
+
+    IF NEW.departamento_superior_id = NEW.id OR EXISTS (
+        WITH RECURSIVE ancestors AS (
+            SELECT id, departamento_superior_id, ARRAY[id] AS caminho
+            FROM departamentos
+            WHERE id = NEW.departamento_superior_id
+
+            UNION ALL
+
+            SELECT d.id, d.departamento_superior_id, a.caminho || d.id
+            FROM departamentos d
+            INNER JOIN ancestors a ON d.id = a.departamento_superior_id
+            WHERE NOT d.id = ANY(a.caminho)
+        )
+        SELECT 1 FROM ancestors WHERE id = NEW.id
+    ) THEN
+        RAISE EXCEPTION 'departamento % cannot be placed under %: hierarchy cycle', NEW.id, NEW.departamento_superior_id
+            USING ERRCODE = 'HC001';
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_departamentos_prevent_cycle_insert
+BEFORE INSERT ON departamentos
+FOR EACH ROW
+WHEN (NEW.departamento_superior_id IS NOT NULL)
+EXECUTE FUNCTION departamentos_prevent_cycle();
+
+CREATE TRIGGER trg_departamentos_prevent_cycle_update
+BEFORE UPDATE OF departamento_superior_id ON departamentos
+FOR EACH ROW
+WHEN (NEW.departamento_superior_id IS NOT NULL AND NEW.departamento_superior_id IS DISTINCT FROM OLD.departamento_superior_id)
+EXECUTE FUNCTION departamentos_prevent_cycle();
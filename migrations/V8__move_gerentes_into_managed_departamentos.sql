@@ -0,0 +1,21 @@
+-- The seed put the gerentes of TI and RH in Diretoria, breaking the rule that
+-- a gerente belongs to the departamento they manage. Move every active
+-- gerente still outside their departamento into it; one who manages more
+-- than one departamento is left for the consistency report, since no single
+-- move can satisfy all of them.
+UPDATE colaboradores c
+SET departamento_id = d.id,
+    version = c.version + 1,
+    updated_at = NOW()
+FROM departamentos d
+WHERE d.gerente_id = c.id
+    AND d.deleted_at IS NULL
+    AND c.deleted_at IS NULL
+    AND c.departamento_id <> d.id
+    AND NOT EXISTS (
+        SELECT 1
+        FROM departamentos other
+        WHERE other.gerente_id = c.id
+            AND other.deleted_at IS NULL
+            AND other.id <> d.id
+    );